@@ -1,24 +1,29 @@
 package main
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/conductorone/baton-sdk/pkg/field"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
 	"github.com/spf13/viper"
 )
 
 var (
 	apiBaseURLField = field.StringField(
 		"api-base-url",
-		field.WithDescription("The Sumo Logic API base URL. Options include:\n"+
-			"- AU: https://api.au.sumologic.com\n"+
-			"- CA: https://api.ca.sumologic.com\n"+
-			"- DE: https://api.de.sumologic.com\n"+
-			"- EU: https://api.eu.sumologic.com\n"+
-			"- FED: https://api.fed.sumologic.com\n"+
-			"- IN: https://api.in.sumologic.com\n"+
-			"- JP: https://api.jp.sumologic.com\n"+
-			"- KR: https://api.kr.sumologic.com\n"+
-			"- US1 (default): https://api.sumologic.com\n"+
-			"- US2: https://api.us2.sumologic.com"),
+		field.WithDescription("The Sumo Logic API base URL, a deployment code, or \"auto\" to detect the deployment of the account. Options include:\n"+
+			"- auto: probe the default endpoint and follow the deployment redirect\n"+
+			"- AU (au): https://api.au.sumologic.com\n"+
+			"- CA (ca): https://api.ca.sumologic.com\n"+
+			"- DE (de): https://api.de.sumologic.com\n"+
+			"- EU (eu): https://api.eu.sumologic.com\n"+
+			"- FED (fed): https://api.fed.sumologic.com\n"+
+			"- IN (in): https://api.in.sumologic.com\n"+
+			"- JP (jp): https://api.jp.sumologic.com\n"+
+			"- KR (kr): https://api.kr.sumologic.com\n"+
+			"- US1 (us1, default): https://api.sumologic.com\n"+
+			"- US2 (us2): https://api.us2.sumologic.com"),
 		field.WithDefaultValue("https://api.sumologic.com"),
 	)
 	apiAccessIDField = field.StringField(
@@ -31,11 +36,48 @@ var (
 		field.WithDescription("The Sumo Logic API access key."),
 		field.WithRequired(true),
 	)
+	rolesAPIVersionField = field.StringField(
+		"roles-api-version",
+		field.WithDescription("The Sumo Logic Roles API version to use. Options include:\n"+
+			"- v2 (default): data access filters (log analytics, audit and security data)\n"+
+			"- v1: single filter predicate, for deployments where v2 is not enabled"),
+		field.WithDefaultValue(client.RolesAPIVersionV2),
+	)
 	includeServiceAccountsField = field.BoolField(
 		"include-service-accounts",
 		field.WithDescription("Whether to include service accounts in the connector."),
 		field.WithDefaultValue(true),
 	)
+	roleGrantsFromUsersField = field.BoolField(
+		"role-grants-from-users",
+		field.WithDescription("Whether to derive role grants from the roles listed on users and service accounts, "+
+			"instead of fetching each role. Recommended for large organizations."),
+		field.WithDefaultValue(false),
+	)
+	includeAccessKeysField = field.BoolField(
+		"include-access-keys",
+		field.WithDescription("Whether to sync the access keys of users and service accounts. "+
+			"Requires the manageAccessKeys capability."),
+		field.WithDefaultValue(false),
+	)
+	accessKeyRotationOverlapField = field.StringField(
+		"access-key-rotation-overlap",
+		field.WithDescription("How long the previous access key of a service account keeps working after a credential rotation, "+
+			"as a duration such as 24h. Keys past the overlap are retired with the retire_access_key action. "+
+			"Defaults to 0s, which lets the rotation retire the replaced key right away."),
+		field.WithDefaultValue("0s"),
+	)
+	disableUsersOnDeprovisionField = field.BoolField(
+		"disable-users-on-deprovision",
+		field.WithDescription("Whether deprovisioning a user disables them instead of deleting them. "+
+			"Disabled users keep their content and can be deleted later."),
+		field.WithDefaultValue(false),
+	)
+	userContentSuccessorField = field.StringField(
+		"user-content-successor",
+		field.WithDescription("The ID or email of the active user who receives the searches, dashboards and monitors of deleted users. "+
+			"When empty, Sumo Logic deletes the content of deleted users."),
+	)
 
 	// ConfigurationFields defines the external configuration required for the
 	// connector to run. Note: these fields can be marked as optional or
@@ -44,7 +86,13 @@ var (
 		apiBaseURLField,
 		apiAccessIDField,
 		apiAccessKeyField,
+		rolesAPIVersionField,
 		includeServiceAccountsField,
+		roleGrantsFromUsersField,
+		includeAccessKeysField,
+		accessKeyRotationOverlapField,
+		disableUsersOnDeprovisionField,
+		userContentSuccessorField,
 	}
 
 	// FieldRelationships defines relationships between the fields listed in
@@ -59,5 +107,34 @@ var (
 // needs to perform extra validations that cannot be encoded with configuration
 // parameters.
 func ValidateConfig(v *viper.Viper) error {
+	apiBaseURL := v.GetString(apiBaseURLField.FieldName)
+	if apiBaseURL != "" && !client.IsValidAPIBaseURL(apiBaseURL) {
+		return fmt.Errorf("invalid %s %q: must be %q, a deployment code or an absolute URL", apiBaseURLField.FieldName, apiBaseURL, client.APIBaseURLAuto)
+	}
+
+	rolesAPIVersion := v.GetString(rolesAPIVersionField.FieldName)
+	if rolesAPIVersion != "" && rolesAPIVersion != client.RolesAPIVersionV1 && rolesAPIVersion != client.RolesAPIVersionV2 {
+		return fmt.Errorf("invalid %s %q: must be %s or %s", rolesAPIVersionField.FieldName, rolesAPIVersion, client.RolesAPIVersionV1, client.RolesAPIVersionV2)
+	}
+
+	if _, err := accessKeyRotationOverlap(v); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// accessKeyRotationOverlap parses the access key rotation overlap, which must be a non-negative duration.
+func accessKeyRotationOverlap(v *viper.Viper) (time.Duration, error) {
+	value := v.GetString(accessKeyRotationOverlapField.FieldName)
+	if value == "" {
+		return 0, nil
+	}
+
+	overlap, err := time.ParseDuration(value)
+	if err != nil || overlap < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative duration such as 24h", accessKeyRotationOverlapField.FieldName, value)
+	}
+
+	return overlap, nil
+}
@@ -14,7 +14,77 @@ func TestConfigs(t *testing.T) {
 	)
 
 	testCases := []test.TestCase{
-		// Add test cases here.
+		{
+			Configs: map[string]string{
+				"api-access-id":  "access-id",
+				"api-access-key": "access-key",
+			},
+			IsValid: true,
+			Message: "default roles API version",
+		},
+		{
+			Configs: map[string]string{
+				"api-access-id":     "access-id",
+				"api-access-key":    "access-key",
+				"roles-api-version": "v1",
+			},
+			IsValid: true,
+			Message: "v1 roles API version",
+		},
+		{
+			Configs: map[string]string{
+				"api-access-id":     "access-id",
+				"api-access-key":    "access-key",
+				"roles-api-version": "v3",
+			},
+			IsValid: false,
+			Message: "unsupported roles API version",
+		},
+		{
+			Configs: map[string]string{
+				"api-access-id":  "access-id",
+				"api-access-key": "access-key",
+				"api-base-url":   "auto",
+			},
+			IsValid: true,
+			Message: "auto detected deployment",
+		},
+		{
+			Configs: map[string]string{
+				"api-access-id":  "access-id",
+				"api-access-key": "access-key",
+				"api-base-url":   "eu",
+			},
+			IsValid: true,
+			Message: "deployment code",
+		},
+		{
+			Configs: map[string]string{
+				"api-access-id":  "access-id",
+				"api-access-key": "access-key",
+				"api-base-url":   "mars",
+			},
+			IsValid: false,
+			Message: "unknown deployment code",
+		},
+		{
+			Configs: map[string]string{
+				"api-access-id":               "access-id",
+				"api-access-key":              "access-key",
+				"access-key-rotation-overlap": "24h",
+			},
+			IsValid: true,
+			Message: "access key rotation overlap",
+		},
+		{
+			Configs: map[string]string{
+				"api-access-id":               "access-id",
+				"api-access-key":              "access-key",
+				"access-key-rotation-overlap": "one day",
+			},
+			IsValid: false,
+			Message: "invalid access key rotation overlap",
+		},
 	}
 
 	test.ExerciseTestCases(t, configurationSchema, ValidateConfig, testCases)
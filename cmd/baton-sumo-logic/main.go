@@ -48,12 +48,27 @@ func getConnector(ctx context.Context, v *viper.Viper) (types.ConnectorServer, e
 		return nil, err
 	}
 
-	apiBaseURL := v.GetString(apiBaseURLField.FieldName)
-	apiAccessID := v.GetString(apiAccessIDField.FieldName)
-	apiAccessKey := v.GetString(apiAccessKeyField.FieldName)
-	includeServiceAccounts := v.GetBool(includeServiceAccountsField.FieldName)
+	rotationOverlap, err := accessKeyRotationOverlap(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// The provisioning flag is defined by the SDK, it is used to check the capabilities of the access key.
+	provisioningEnabled := v.GetBool("provisioning")
 
-	cb, err := connector.New(ctx, apiBaseURL, apiAccessID, apiAccessKey, includeServiceAccounts)
+	cb, err := connector.New(ctx, connector.Config{
+		APIBaseURL:                v.GetString(apiBaseURLField.FieldName),
+		APIAccessID:               v.GetString(apiAccessIDField.FieldName),
+		APIAccessKey:              v.GetString(apiAccessKeyField.FieldName),
+		RolesAPIVersion:           v.GetString(rolesAPIVersionField.FieldName),
+		IncludeServiceAccounts:    v.GetBool(includeServiceAccountsField.FieldName),
+		RoleGrantsFromUsers:       v.GetBool(roleGrantsFromUsersField.FieldName),
+		IncludeAccessKeys:         v.GetBool(includeAccessKeysField.FieldName),
+		ProvisioningEnabled:       provisioningEnabled,
+		AccessKeyRotationOverlap:  rotationOverlap,
+		DisableUsersOnDeprovision: v.GetBool(disableUsersOnDeprovisionField.FieldName),
+		UserContentSuccessor:      v.GetString(userContentSuccessorField.FieldName),
+	})
 	if err != nil {
 		l.Error("error creating connector", zap.Error(err))
 		return nil, err
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
@@ -12,16 +13,32 @@ import (
 const (
 	apiVersion       = "v1"
 	resourcePageSize = 100 // API: Default value is 100 and the range is 1-100.
+
+	// RolesAPIVersionV1 selects the v1 Roles API, which exposes a single filter predicate per role.
+	RolesAPIVersionV1 = "v1"
+	// RolesAPIVersionV2 selects the v2 Roles API, which exposes separate data access filters per role.
+	RolesAPIVersionV2 = "v2"
 )
 
 type Client struct {
-	httpClient *uhttp.BaseHttpClient
-	apiBaseURL *url.URL
+	httpClient      *uhttp.BaseHttpClient
+	apiBaseURL      *url.URL
+	rolesAPIVersion string
 }
 
-func NewClient(ctx context.Context, apiBaseURL, apiAccessID, apiAccessKey string) (*Client, error) {
+func NewClient(ctx context.Context, apiBaseURL, apiAccessID, apiAccessKey, rolesAPIVersion string) (*Client, error) {
+	if rolesAPIVersion != RolesAPIVersionV1 && rolesAPIVersion != RolesAPIVersionV2 {
+		return nil, fmt.Errorf("unsupported roles API version: %s", rolesAPIVersion)
+	}
+
+	// Resolve deployment codes and the auto mode into an API base URL
+	baseURL, detectDeployment, err := resolveAPIBaseURL(apiBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create API base URL
-	url, err := url.Parse(apiBaseURL)
+	url, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing API base URL: %w", err)
 	}
@@ -33,6 +50,11 @@ func NewClient(ctx context.Context, apiBaseURL, apiAccessID, apiAccessKey string
 	if err != nil {
 		return nil, fmt.Errorf("error creating http client: %w", err)
 	}
+	// Sumo Logic answers requests sent to the wrong deployment with a redirect to the right one.
+	// Following it would drop the credentials, so the redirect is returned to the caller instead.
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
 
 	// Create the base HTTP client with the authenticated client
 	baseClient, err := uhttp.NewBaseHttpClientWithContext(ctx, httpClient)
@@ -40,10 +62,20 @@ func NewClient(ctx context.Context, apiBaseURL, apiAccessID, apiAccessKey string
 		return nil, fmt.Errorf("error creating base http client: %w", err)
 	}
 
-	return &Client{
-		httpClient: baseClient,
-		apiBaseURL: url,
-	}, nil
+	client := &Client{
+		httpClient:      baseClient,
+		apiBaseURL:      url,
+		rolesAPIVersion: rolesAPIVersion,
+	}
+
+	// The detected deployment is pinned for the lifetime of the client.
+	if detectDeployment {
+		if err := client.detectDeployment(ctx); err != nil {
+			return nil, fmt.Errorf("error detecting the Sumo Logic deployment: %w", err)
+		}
+	}
+
+	return client, nil
 }
 
 // GetUsers retrieves users from the API.
@@ -98,6 +130,110 @@ func (c *Client) getServiceAccounts(ctx context.Context) (
 	return response.Data, rateLimit, nil
 }
 
+// getServiceAccountByID retrieves a service account by ID.
+func (c *Client) getServiceAccountByID(ctx context.Context, serviceAccountId string) (
+	*ServiceAccountResponse,
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/getServiceAccount
+	path := "/api/{{.apiVersion}}/serviceAccounts/{{.serviceAccountID}}"
+	pathParameters := map[string]string{"apiVersion": apiVersion, "serviceAccountID": serviceAccountId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating get service account by ID URL: %w", err)
+	}
+
+	var response ServiceAccountResponse
+	rateLimit, err := c.get(ctx, url, &response)
+	if err != nil {
+		return nil, rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return &response, rateLimit, nil
+}
+
+func (c *Client) createServiceAccount(ctx context.Context, serviceAccountRequest ServiceAccountRequest) (
+	*ServiceAccountResponse,
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/createServiceAccount
+	path := "/api/{{.apiVersion}}/serviceAccounts"
+	pathParameters := map[string]string{"apiVersion": apiVersion}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating create service account URL: %w", err)
+	}
+
+	payload := map[string]interface{}{
+		"name":    serviceAccountRequest.Name,
+		"email":   serviceAccountRequest.Email,
+		"roleIds": serviceAccountRequest.RoleIDs,
+	}
+
+	var response ServiceAccountResponse
+	rateLimit, err := c.post(ctx, url, &response, payload)
+	if err != nil {
+		return nil, rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return &response, rateLimit, nil
+}
+
+func (c *Client) deleteServiceAccount(ctx context.Context, serviceAccountId string) (
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/deleteServiceAccount
+	path := "/api/{{.apiVersion}}/serviceAccounts/{{.serviceAccountID}}"
+	pathParameters := map[string]string{"apiVersion": apiVersion, "serviceAccountID": serviceAccountId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error generating delete service account URL: %w", err)
+	}
+
+	rateLimit, err := c.delete(ctx, url, nil)
+	if err != nil {
+		return rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return rateLimit, nil
+}
+
+func (c *Client) updateServiceAccount(ctx context.Context, serviceAccountId string, serviceAccountRequest ServiceAccountRequest) (
+	*ServiceAccountResponse,
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/updateServiceAccount
+	path := "/api/{{.apiVersion}}/serviceAccounts/{{.serviceAccountID}}"
+	pathParameters := map[string]string{"apiVersion": apiVersion, "serviceAccountID": serviceAccountId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating update service account URL: %w", err)
+	}
+
+	// The update endpoint replaces the whole service account, so every field must be sent.
+	payload := map[string]interface{}{
+		"name":    serviceAccountRequest.Name,
+		"email":   serviceAccountRequest.Email,
+		"roleIds": serviceAccountRequest.RoleIDs,
+	}
+
+	var response ServiceAccountResponse
+	rateLimit, err := c.put(ctx, url, &response, payload)
+	if err != nil {
+		return nil, rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return &response, rateLimit, nil
+}
+
 // GetRoles retrieves roles from the API.
 func (c *Client) getRoles(ctx context.Context, pageToken *string) (
 	[]*RoleResponse,
@@ -106,10 +242,9 @@ func (c *Client) getRoles(ctx context.Context, pageToken *string) (
 	error,
 ) {
 	// API Doc: https://api.sumologic.com/docs/#operation/listRoles
+	// API Doc: https://api.sumologic.com/docs/#operation/listRolesV2
 	path := "/api/{{.apiVersion}}/roles"
-	pathParameters := map[string]string{"apiVersion": apiVersion}
-
-	var response ApiResponse[RoleResponse]
+	pathParameters := map[string]string{"apiVersion": c.rolesAPIVersion}
 
 	pageSize := uint(resourcePageSize)
 	url, err := c.constructURL(path, pathParameters, nil, pageToken, &pageSize)
@@ -117,12 +252,18 @@ func (c *Client) getRoles(ctx context.Context, pageToken *string) (
 		return nil, nil, nil, fmt.Errorf("error generating role list URL: %w", err)
 	}
 
+	var response ApiResponse[RoleV2Response]
 	rateLimit, err := c.get(ctx, url, &response)
 	if err != nil {
 		return nil, nil, rateLimit, fmt.Errorf("error executing request: %w", err)
 	}
 
-	return response.Data, response.Next, rateLimit, nil
+	roles := make([]*RoleResponse, 0, len(response.Data))
+	for _, role := range response.Data {
+		roles = append(roles, c.toRoleResponse(role))
+	}
+
+	return roles, response.Next, rateLimit, nil
 }
 
 // GetRole retrieves a role by ID.
@@ -131,23 +272,94 @@ func (c *Client) getRole(ctx context.Context, roleId string) (
 	*v2.RateLimitDescription,
 	error,
 ) {
-	// API Doc: https://api.sumologic.com/docs/#operation/listRoles
+	// API Doc: https://api.sumologic.com/docs/#operation/getRole
+	// API Doc: https://api.sumologic.com/docs/#operation/getRoleV2
 	path := "/api/{{.apiVersion}}/roles/{{.roleID}}"
-	pathParameters := map[string]string{"apiVersion": apiVersion, "roleID": roleId}
-
-	var response RoleResponse
+	pathParameters := map[string]string{"apiVersion": c.rolesAPIVersion, "roleID": roleId}
 
 	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error generating role list URL: %w", err)
 	}
 
+	var response RoleV2Response
 	rateLimit, err := c.get(ctx, url, &response)
 	if err != nil {
 		return nil, rateLimit, fmt.Errorf("error executing request: %w", err)
 	}
 
-	return &response, rateLimit, nil
+	return c.toRoleResponse(&response), rateLimit, nil
+}
+
+func (c *Client) createRole(ctx context.Context, roleRequest RoleRequest) (
+	*RoleResponse,
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/createRole
+	// API Doc: https://api.sumologic.com/docs/#operation/createRoleV2
+	path := "/api/{{.apiVersion}}/roles"
+	pathParameters := map[string]string{"apiVersion": c.rolesAPIVersion}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating create role URL: %w", err)
+	}
+
+	var response RoleV2Response
+	rateLimit, err := c.post(ctx, url, &response, roleRequestPayload(c.rolesAPIVersion, roleRequest))
+	if err != nil {
+		return nil, rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return c.toRoleResponse(&response), rateLimit, nil
+}
+
+func (c *Client) deleteRole(ctx context.Context, roleId string) (
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/deleteRole
+	// API Doc: https://api.sumologic.com/docs/#operation/deleteRoleV2
+	path := "/api/{{.apiVersion}}/roles/{{.roleID}}"
+	pathParameters := map[string]string{"apiVersion": c.rolesAPIVersion, "roleID": roleId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error generating delete role URL: %w", err)
+	}
+
+	rateLimit, err := c.delete(ctx, url, nil)
+	if err != nil {
+		return rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return rateLimit, nil
+}
+
+func (c *Client) updateRole(ctx context.Context, roleId string, roleRequest RoleRequest) (
+	*RoleResponse,
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/updateRole
+	// API Doc: https://api.sumologic.com/docs/#operation/updateRoleV2
+	path := "/api/{{.apiVersion}}/roles/{{.roleID}}"
+	pathParameters := map[string]string{"apiVersion": c.rolesAPIVersion, "roleID": roleId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating update role URL: %w", err)
+	}
+
+	// The update endpoint replaces the whole role, so every field must be sent.
+	var response RoleV2Response
+	rateLimit, err := c.put(ctx, url, &response, roleRequestPayload(c.rolesAPIVersion, roleRequest))
+	if err != nil {
+		return nil, rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return c.toRoleResponse(&response), rateLimit, nil
 }
 
 func (c *Client) assignRoleToUser(ctx context.Context, roleId string, userId string) (
@@ -166,7 +378,7 @@ func (c *Client) assignRoleToUser(ctx context.Context, roleId string, userId str
 		return nil, nil, fmt.Errorf("error generating assign role to user URL: %w", err)
 	}
 
-	rateLimit, err := c.put(ctx, url, &response)
+	rateLimit, err := c.put(ctx, url, &response, nil)
 	if err != nil {
 		return nil, rateLimit, fmt.Errorf("error executing request: %w", err)
 	}
@@ -248,15 +460,148 @@ func (c *Client) createUser(ctx context.Context, userRequest UserRequest) (
 	return &response, rateLimit, nil
 }
 
-func (c *Client) deleteUser(ctx context.Context, userId string) (
+func (c *Client) updateUser(ctx context.Context, userId string, userRequest UserUpdateRequest) (
+	*UserResponse,
 	*v2.RateLimitDescription,
 	error,
 ) {
-	// API Doc: https://api.sumologic.com/docs/#operation/deleteUser
+	// API Doc: https://api.sumologic.com/docs/#operation/updateUser
 	path := "/api/{{.apiVersion}}/users/{{.userID}}"
 	pathParameters := map[string]string{"apiVersion": apiVersion, "userID": userId}
 
 	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating update user URL: %w", err)
+	}
+
+	// The update endpoint replaces the whole user, so every field must be sent.
+	payload := map[string]interface{}{
+		"firstName": userRequest.FirstName,
+		"lastName":  userRequest.LastName,
+		"isActive":  userRequest.IsActive,
+		"roleIds":   userRequest.RoleIDs,
+	}
+
+	var response UserResponse
+	rateLimit, err := c.put(ctx, url, &response, payload)
+	if err != nil {
+		return nil, rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return &response, rateLimit, nil
+}
+
+func (c *Client) unlockUser(ctx context.Context, userId string) (
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/unlockUser
+	path := "/api/{{.apiVersion}}/users/{{.userID}}/unlock"
+	pathParameters := map[string]string{"apiVersion": apiVersion, "userID": userId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error generating unlock user URL: %w", err)
+	}
+
+	rateLimit, err := c.post(ctx, url, nil, nil)
+	if err != nil {
+		return rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return rateLimit, nil
+}
+
+func (c *Client) resetPassword(ctx context.Context, userId string) (
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/resetPassword
+	path := "/api/{{.apiVersion}}/users/{{.userID}}/password/reset"
+	pathParameters := map[string]string{"apiVersion": apiVersion, "userID": userId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error generating reset password URL: %w", err)
+	}
+
+	rateLimit, err := c.post(ctx, url, nil, nil)
+	if err != nil {
+		return rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return rateLimit, nil
+}
+
+func (c *Client) disableMfa(ctx context.Context, userId string, email string, password string) (
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/disableMfa
+	path := "/api/{{.apiVersion}}/users/{{.userID}}/mfa/disable"
+	pathParameters := map[string]string{"apiVersion": apiVersion, "userID": userId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error generating disable MFA URL: %w", err)
+	}
+
+	// Sumo Logic only disables MFA when given the email and password of the user.
+	payload := map[string]interface{}{
+		"email":    email,
+		"password": password,
+	}
+
+	rateLimit, err := c.put(ctx, url, nil, payload)
+	if err != nil {
+		return rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return rateLimit, nil
+}
+
+// requestChangeEmail starts the change of the email of a user, it takes effect once the user confirms the new address.
+func (c *Client) requestChangeEmail(ctx context.Context, userId string, email string) (
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/requestChangeEmail
+	path := "/api/{{.apiVersion}}/users/{{.userID}}/email/requestChange"
+	pathParameters := map[string]string{"apiVersion": apiVersion, "userID": userId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error generating request change email URL: %w", err)
+	}
+
+	payload := map[string]interface{}{
+		"email": email,
+	}
+
+	rateLimit, err := c.post(ctx, url, nil, payload)
+	if err != nil {
+		return rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return rateLimit, nil
+}
+
+// deleteUser deletes a user. When transferTo is set, the content of the user is transferred to that user,
+// otherwise Sumo Logic deletes it.
+func (c *Client) deleteUser(ctx context.Context, userId string, transferTo string) (
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/deleteUser
+	path := "/api/{{.apiVersion}}/users/{{.userID}}"
+	pathParameters := map[string]string{"apiVersion": apiVersion, "userID": userId}
+
+	var queryParameters map[string]string
+	if transferTo != "" {
+		queryParameters = map[string]string{"transferTo": transferTo}
+	}
+
+	url, err := c.constructURL(path, pathParameters, queryParameters, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error generating delete user URL: %w", err)
 	}
@@ -268,3 +613,153 @@ func (c *Client) deleteUser(ctx context.Context, userId string) (
 
 	return rateLimit, nil
 }
+
+func (c *Client) getPersonalAccessKeys(ctx context.Context) (
+	[]*AccessKeyResponse,
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/listPersonalAccessKeys
+	path := "/api/{{.apiVersion}}/accessKeys/personal"
+	pathParameters := map[string]string{"apiVersion": apiVersion}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating personal access key list URL: %w", err)
+	}
+
+	var response ApiResponse[AccessKeyResponse]
+	rateLimit, err := c.get(ctx, url, &response)
+	if err != nil {
+		return nil, rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return response.Data, rateLimit, nil
+}
+
+func (c *Client) getAccessKeys(ctx context.Context, pageToken *string) (
+	[]*AccessKeyResponse,
+	*string,
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/listAccessKeys
+	path := "/api/{{.apiVersion}}/accessKeys"
+	pathParameters := map[string]string{"apiVersion": apiVersion}
+
+	pageSize := uint(resourcePageSize)
+	url, err := c.constructURL(path, pathParameters, nil, pageToken, &pageSize)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error generating access key list URL: %w", err)
+	}
+
+	var response ApiResponse[AccessKeyResponse]
+	rateLimit, err := c.get(ctx, url, &response)
+	if err != nil {
+		return nil, nil, rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return response.Data, response.Next, rateLimit, nil
+}
+
+func (c *Client) createServiceAccountAccessKey(ctx context.Context, serviceAccountId string, label string) (
+	*AccessKeyCreateResponse,
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/createServiceAccountAccessKey
+	path := "/api/{{.apiVersion}}/serviceAccounts/{{.serviceAccountID}}/accessKeys"
+	pathParameters := map[string]string{"apiVersion": apiVersion, "serviceAccountID": serviceAccountId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating create service account access key URL: %w", err)
+	}
+
+	payload := map[string]interface{}{
+		"label": label,
+	}
+
+	var response AccessKeyCreateResponse
+	rateLimit, err := c.post(ctx, url, &response, payload)
+	if err != nil {
+		return nil, rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return &response, rateLimit, nil
+}
+
+func (c *Client) updateAccessKey(ctx context.Context, accessKeyId string, accessKeyRequest AccessKeyUpdateRequest) (
+	*AccessKeyResponse,
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/updateAccessKey
+	path := "/api/{{.apiVersion}}/accessKeys/{{.accessKeyID}}"
+	pathParameters := map[string]string{"apiVersion": apiVersion, "accessKeyID": accessKeyId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating update access key URL: %w", err)
+	}
+
+	payload := map[string]interface{}{
+		"disabled": accessKeyRequest.Disabled,
+	}
+	if accessKeyRequest.CorsHeaders != nil {
+		payload["corsHeaders"] = accessKeyRequest.CorsHeaders
+	}
+
+	var response AccessKeyResponse
+	rateLimit, err := c.put(ctx, url, &response, payload)
+	if err != nil {
+		return nil, rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return &response, rateLimit, nil
+}
+
+func (c *Client) deleteAccessKey(ctx context.Context, accessKeyId string) (
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/deleteAccessKey
+	path := "/api/{{.apiVersion}}/accessKeys/{{.accessKeyID}}"
+	pathParameters := map[string]string{"apiVersion": apiVersion, "accessKeyID": accessKeyId}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error generating delete access key URL: %w", err)
+	}
+
+	rateLimit, err := c.delete(ctx, url, nil)
+	if err != nil {
+		return rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return rateLimit, nil
+}
+
+func (c *Client) getAccountOwner(ctx context.Context) (
+	string,
+	*v2.RateLimitDescription,
+	error,
+) {
+	// API Doc: https://api.sumologic.com/docs/#operation/getAccountOwner
+	path := "/api/{{.apiVersion}}/account/accountOwner"
+	pathParameters := map[string]string{"apiVersion": apiVersion}
+
+	url, err := c.constructURL(path, pathParameters, nil, nil, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("error generating get account owner URL: %w", err)
+	}
+
+	// The response is the identifier of the owner as a JSON string.
+	var response string
+	rateLimit, err := c.get(ctx, url, &response)
+	if err != nil {
+		return "", rateLimit, fmt.Errorf("error executing request: %w", err)
+	}
+
+	return response, rateLimit, nil
+}
@@ -11,12 +11,30 @@ type ClientService interface {
 	GetUserByID(ctx context.Context, userId string) (*UserResponse, *v2.RateLimitDescription, error)
 	GetUsers(ctx context.Context, pageToken *string) ([]*UserResponse, *string, *v2.RateLimitDescription, error)
 	CreateUser(ctx context.Context, userRequest UserRequest) (*UserResponse, *v2.RateLimitDescription, error)
-	DeleteUser(ctx context.Context, userId string) (*v2.RateLimitDescription, error)
+	UpdateUser(ctx context.Context, userId string, userRequest UserUpdateRequest) (*UserResponse, *v2.RateLimitDescription, error)
+	DeleteUser(ctx context.Context, userId string, transferTo string) (*v2.RateLimitDescription, error)
+	UnlockUser(ctx context.Context, userId string) (*v2.RateLimitDescription, error)
+	ResetPassword(ctx context.Context, userId string) (*v2.RateLimitDescription, error)
+	DisableMfa(ctx context.Context, userId string, email string, password string) (*v2.RateLimitDescription, error)
+	RequestChangeEmail(ctx context.Context, userId string, email string) (*v2.RateLimitDescription, error)
 	GetServiceAccounts(ctx context.Context) ([]*ServiceAccountResponse, *v2.RateLimitDescription, error)
+	GetServiceAccountByID(ctx context.Context, serviceAccountId string) (*ServiceAccountResponse, *v2.RateLimitDescription, error)
+	CreateServiceAccount(ctx context.Context, serviceAccountRequest ServiceAccountRequest) (*ServiceAccountResponse, *v2.RateLimitDescription, error)
+	DeleteServiceAccount(ctx context.Context, serviceAccountId string) (*v2.RateLimitDescription, error)
+	UpdateServiceAccount(ctx context.Context, serviceAccountId string, serviceAccountRequest ServiceAccountRequest) (*ServiceAccountResponse, *v2.RateLimitDescription, error)
 	GetRoles(ctx context.Context, pageToken *string) ([]*RoleResponse, *string, *v2.RateLimitDescription, error)
 	GetRole(ctx context.Context, roleId string) (*RoleResponse, *v2.RateLimitDescription, error)
+	CreateRole(ctx context.Context, roleRequest RoleRequest) (*RoleResponse, *v2.RateLimitDescription, error)
+	DeleteRole(ctx context.Context, roleId string) (*v2.RateLimitDescription, error)
+	UpdateRole(ctx context.Context, roleId string, roleRequest RoleRequest) (*RoleResponse, *v2.RateLimitDescription, error)
 	AssignRoleToUser(ctx context.Context, roleId string, userId string) (*RoleResponse, *v2.RateLimitDescription, error)
 	RemoveRoleFromUser(ctx context.Context, roleId string, userId string) (*v2.RateLimitDescription, error)
+	GetPersonalAccessKeys(ctx context.Context) ([]*AccessKeyResponse, *v2.RateLimitDescription, error)
+	GetAccessKeys(ctx context.Context, pageToken *string) ([]*AccessKeyResponse, *string, *v2.RateLimitDescription, error)
+	CreateServiceAccountAccessKey(ctx context.Context, serviceAccountId string, label string) (*AccessKeyCreateResponse, *v2.RateLimitDescription, error)
+	UpdateAccessKey(ctx context.Context, accessKeyId string, accessKeyRequest AccessKeyUpdateRequest) (*AccessKeyResponse, *v2.RateLimitDescription, error)
+	DeleteAccessKey(ctx context.Context, accessKeyId string) (*v2.RateLimitDescription, error)
+	GetAccountOwner(ctx context.Context) (string, *v2.RateLimitDescription, error)
 }
 
 // ClientServiceImpl is the default implementation that calls the actual API.
@@ -36,8 +54,28 @@ func (s *ClientServiceImpl) CreateUser(ctx context.Context, userRequest UserRequ
 	return s.client.createUser(ctx, userRequest)
 }
 
-func (s *ClientServiceImpl) DeleteUser(ctx context.Context, userId string) (*v2.RateLimitDescription, error) {
-	return s.client.deleteUser(ctx, userId)
+func (s *ClientServiceImpl) UpdateUser(ctx context.Context, userId string, userRequest UserUpdateRequest) (*UserResponse, *v2.RateLimitDescription, error) {
+	return s.client.updateUser(ctx, userId, userRequest)
+}
+
+func (s *ClientServiceImpl) UnlockUser(ctx context.Context, userId string) (*v2.RateLimitDescription, error) {
+	return s.client.unlockUser(ctx, userId)
+}
+
+func (s *ClientServiceImpl) ResetPassword(ctx context.Context, userId string) (*v2.RateLimitDescription, error) {
+	return s.client.resetPassword(ctx, userId)
+}
+
+func (s *ClientServiceImpl) DisableMfa(ctx context.Context, userId string, email string, password string) (*v2.RateLimitDescription, error) {
+	return s.client.disableMfa(ctx, userId, email, password)
+}
+
+func (s *ClientServiceImpl) RequestChangeEmail(ctx context.Context, userId string, email string) (*v2.RateLimitDescription, error) {
+	return s.client.requestChangeEmail(ctx, userId, email)
+}
+
+func (s *ClientServiceImpl) DeleteUser(ctx context.Context, userId string, transferTo string) (*v2.RateLimitDescription, error) {
+	return s.client.deleteUser(ctx, userId, transferTo)
 }
 
 func (s *ClientServiceImpl) GetUsers(ctx context.Context, pageToken *string) ([]*UserResponse, *string, *v2.RateLimitDescription, error) {
@@ -48,6 +86,22 @@ func (s *ClientServiceImpl) GetServiceAccounts(ctx context.Context) ([]*ServiceA
 	return s.client.getServiceAccounts(ctx)
 }
 
+func (s *ClientServiceImpl) GetServiceAccountByID(ctx context.Context, serviceAccountId string) (*ServiceAccountResponse, *v2.RateLimitDescription, error) {
+	return s.client.getServiceAccountByID(ctx, serviceAccountId)
+}
+
+func (s *ClientServiceImpl) CreateServiceAccount(ctx context.Context, serviceAccountRequest ServiceAccountRequest) (*ServiceAccountResponse, *v2.RateLimitDescription, error) {
+	return s.client.createServiceAccount(ctx, serviceAccountRequest)
+}
+
+func (s *ClientServiceImpl) DeleteServiceAccount(ctx context.Context, serviceAccountId string) (*v2.RateLimitDescription, error) {
+	return s.client.deleteServiceAccount(ctx, serviceAccountId)
+}
+
+func (s *ClientServiceImpl) UpdateServiceAccount(ctx context.Context, serviceAccountId string, serviceAccountRequest ServiceAccountRequest) (*ServiceAccountResponse, *v2.RateLimitDescription, error) {
+	return s.client.updateServiceAccount(ctx, serviceAccountId, serviceAccountRequest)
+}
+
 func (s *ClientServiceImpl) GetRoles(ctx context.Context, pageToken *string) ([]*RoleResponse, *string, *v2.RateLimitDescription, error) {
 	return s.client.getRoles(ctx, pageToken)
 }
@@ -56,6 +110,18 @@ func (s *ClientServiceImpl) GetRole(ctx context.Context, roleId string) (*RoleRe
 	return s.client.getRole(ctx, roleId)
 }
 
+func (s *ClientServiceImpl) CreateRole(ctx context.Context, roleRequest RoleRequest) (*RoleResponse, *v2.RateLimitDescription, error) {
+	return s.client.createRole(ctx, roleRequest)
+}
+
+func (s *ClientServiceImpl) DeleteRole(ctx context.Context, roleId string) (*v2.RateLimitDescription, error) {
+	return s.client.deleteRole(ctx, roleId)
+}
+
+func (s *ClientServiceImpl) UpdateRole(ctx context.Context, roleId string, roleRequest RoleRequest) (*RoleResponse, *v2.RateLimitDescription, error) {
+	return s.client.updateRole(ctx, roleId, roleRequest)
+}
+
 func (s *ClientServiceImpl) AssignRoleToUser(ctx context.Context, roleId string, userId string) (*RoleResponse, *v2.RateLimitDescription, error) {
 	return s.client.assignRoleToUser(ctx, roleId, userId)
 }
@@ -63,3 +129,27 @@ func (s *ClientServiceImpl) AssignRoleToUser(ctx context.Context, roleId string,
 func (s *ClientServiceImpl) RemoveRoleFromUser(ctx context.Context, roleId string, userId string) (*v2.RateLimitDescription, error) {
 	return s.client.removeRoleFromUser(ctx, roleId, userId)
 }
+
+func (s *ClientServiceImpl) GetPersonalAccessKeys(ctx context.Context) ([]*AccessKeyResponse, *v2.RateLimitDescription, error) {
+	return s.client.getPersonalAccessKeys(ctx)
+}
+
+func (s *ClientServiceImpl) GetAccessKeys(ctx context.Context, pageToken *string) ([]*AccessKeyResponse, *string, *v2.RateLimitDescription, error) {
+	return s.client.getAccessKeys(ctx, pageToken)
+}
+
+func (s *ClientServiceImpl) CreateServiceAccountAccessKey(ctx context.Context, serviceAccountId string, label string) (*AccessKeyCreateResponse, *v2.RateLimitDescription, error) {
+	return s.client.createServiceAccountAccessKey(ctx, serviceAccountId, label)
+}
+
+func (s *ClientServiceImpl) UpdateAccessKey(ctx context.Context, accessKeyId string, accessKeyRequest AccessKeyUpdateRequest) (*AccessKeyResponse, *v2.RateLimitDescription, error) {
+	return s.client.updateAccessKey(ctx, accessKeyId, accessKeyRequest)
+}
+
+func (s *ClientServiceImpl) DeleteAccessKey(ctx context.Context, accessKeyId string) (*v2.RateLimitDescription, error) {
+	return s.client.deleteAccessKey(ctx, accessKeyId)
+}
+
+func (s *ClientServiceImpl) GetAccountOwner(ctx context.Context) (string, *v2.RateLimitDescription, error) {
+	return s.client.getAccountOwner(ctx)
+}
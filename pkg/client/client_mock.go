@@ -7,15 +7,33 @@ import (
 )
 
 type MockClientService struct {
-	GetUserByIDFunc        func(ctx context.Context, userId string) (*UserResponse, *v2.RateLimitDescription, error)
-	CreateUserFunc         func(ctx context.Context, userRequest UserRequest) (*UserResponse, *v2.RateLimitDescription, error)
-	DeleteUserFunc         func(ctx context.Context, userId string) (*v2.RateLimitDescription, error)
-	GetUsersFunc           func(ctx context.Context, pageToken *string) ([]*UserResponse, *string, *v2.RateLimitDescription, error)
-	GetServiceAccountsFunc func(ctx context.Context) ([]*ServiceAccountResponse, *v2.RateLimitDescription, error)
-	GetRolesFunc           func(ctx context.Context, pageToken *string) ([]*RoleResponse, *string, *v2.RateLimitDescription, error)
-	GetRoleFunc            func(ctx context.Context, roleId string) (*RoleResponse, *v2.RateLimitDescription, error)
-	AssignRoleToUserFunc   func(ctx context.Context, roleId string, userId string) (*RoleResponse, *v2.RateLimitDescription, error)
-	RemoveRoleFromUserFunc func(ctx context.Context, roleId string, userId string) (*v2.RateLimitDescription, error)
+	GetUserByIDFunc                   func(ctx context.Context, userId string) (*UserResponse, *v2.RateLimitDescription, error)
+	CreateUserFunc                    func(ctx context.Context, userRequest UserRequest) (*UserResponse, *v2.RateLimitDescription, error)
+	UpdateUserFunc                    func(ctx context.Context, userId string, userRequest UserUpdateRequest) (*UserResponse, *v2.RateLimitDescription, error)
+	UnlockUserFunc                    func(ctx context.Context, userId string) (*v2.RateLimitDescription, error)
+	ResetPasswordFunc                 func(ctx context.Context, userId string) (*v2.RateLimitDescription, error)
+	DisableMfaFunc                    func(ctx context.Context, userId string, email string, password string) (*v2.RateLimitDescription, error)
+	RequestChangeEmailFunc            func(ctx context.Context, userId string, email string) (*v2.RateLimitDescription, error)
+	DeleteUserFunc                    func(ctx context.Context, userId string, transferTo string) (*v2.RateLimitDescription, error)
+	GetUsersFunc                      func(ctx context.Context, pageToken *string) ([]*UserResponse, *string, *v2.RateLimitDescription, error)
+	GetServiceAccountsFunc            func(ctx context.Context) ([]*ServiceAccountResponse, *v2.RateLimitDescription, error)
+	GetServiceAccountByIDFunc         func(ctx context.Context, serviceAccountId string) (*ServiceAccountResponse, *v2.RateLimitDescription, error)
+	CreateServiceAccountFunc          func(ctx context.Context, serviceAccountRequest ServiceAccountRequest) (*ServiceAccountResponse, *v2.RateLimitDescription, error)
+	DeleteServiceAccountFunc          func(ctx context.Context, serviceAccountId string) (*v2.RateLimitDescription, error)
+	UpdateServiceAccountFunc          func(ctx context.Context, serviceAccountId string, serviceAccountRequest ServiceAccountRequest) (*ServiceAccountResponse, *v2.RateLimitDescription, error)
+	GetRolesFunc                      func(ctx context.Context, pageToken *string) ([]*RoleResponse, *string, *v2.RateLimitDescription, error)
+	GetRoleFunc                       func(ctx context.Context, roleId string) (*RoleResponse, *v2.RateLimitDescription, error)
+	CreateRoleFunc                    func(ctx context.Context, roleRequest RoleRequest) (*RoleResponse, *v2.RateLimitDescription, error)
+	DeleteRoleFunc                    func(ctx context.Context, roleId string) (*v2.RateLimitDescription, error)
+	UpdateRoleFunc                    func(ctx context.Context, roleId string, roleRequest RoleRequest) (*RoleResponse, *v2.RateLimitDescription, error)
+	AssignRoleToUserFunc              func(ctx context.Context, roleId string, userId string) (*RoleResponse, *v2.RateLimitDescription, error)
+	RemoveRoleFromUserFunc            func(ctx context.Context, roleId string, userId string) (*v2.RateLimitDescription, error)
+	GetPersonalAccessKeysFunc         func(ctx context.Context) ([]*AccessKeyResponse, *v2.RateLimitDescription, error)
+	CreateServiceAccountAccessKeyFunc func(ctx context.Context, serviceAccountId string, label string) (*AccessKeyCreateResponse, *v2.RateLimitDescription, error)
+	UpdateAccessKeyFunc               func(ctx context.Context, accessKeyId string, accessKeyRequest AccessKeyUpdateRequest) (*AccessKeyResponse, *v2.RateLimitDescription, error)
+	DeleteAccessKeyFunc               func(ctx context.Context, accessKeyId string) (*v2.RateLimitDescription, error)
+	GetAccountOwnerFunc               func(ctx context.Context) (string, *v2.RateLimitDescription, error)
+	GetAccessKeysFunc                 func(ctx context.Context, pageToken *string) ([]*AccessKeyResponse, *string, *v2.RateLimitDescription, error)
 }
 
 func (m *MockClientService) GetUserByID(ctx context.Context, userId string) (*UserResponse, *v2.RateLimitDescription, error) {
@@ -26,8 +44,28 @@ func (m *MockClientService) CreateUser(ctx context.Context, userRequest UserRequ
 	return m.CreateUserFunc(ctx, userRequest)
 }
 
-func (m *MockClientService) DeleteUser(ctx context.Context, userId string) (*v2.RateLimitDescription, error) {
-	return m.DeleteUserFunc(ctx, userId)
+func (m *MockClientService) UpdateUser(ctx context.Context, userId string, userRequest UserUpdateRequest) (*UserResponse, *v2.RateLimitDescription, error) {
+	return m.UpdateUserFunc(ctx, userId, userRequest)
+}
+
+func (m *MockClientService) UnlockUser(ctx context.Context, userId string) (*v2.RateLimitDescription, error) {
+	return m.UnlockUserFunc(ctx, userId)
+}
+
+func (m *MockClientService) ResetPassword(ctx context.Context, userId string) (*v2.RateLimitDescription, error) {
+	return m.ResetPasswordFunc(ctx, userId)
+}
+
+func (m *MockClientService) DisableMfa(ctx context.Context, userId string, email string, password string) (*v2.RateLimitDescription, error) {
+	return m.DisableMfaFunc(ctx, userId, email, password)
+}
+
+func (m *MockClientService) RequestChangeEmail(ctx context.Context, userId string, email string) (*v2.RateLimitDescription, error) {
+	return m.RequestChangeEmailFunc(ctx, userId, email)
+}
+
+func (m *MockClientService) DeleteUser(ctx context.Context, userId string, transferTo string) (*v2.RateLimitDescription, error) {
+	return m.DeleteUserFunc(ctx, userId, transferTo)
 }
 
 func (m *MockClientService) GetUsers(ctx context.Context, pageToken *string) ([]*UserResponse, *string, *v2.RateLimitDescription, error) {
@@ -38,6 +76,22 @@ func (m *MockClientService) GetServiceAccounts(ctx context.Context) ([]*ServiceA
 	return m.GetServiceAccountsFunc(ctx)
 }
 
+func (m *MockClientService) GetServiceAccountByID(ctx context.Context, serviceAccountId string) (*ServiceAccountResponse, *v2.RateLimitDescription, error) {
+	return m.GetServiceAccountByIDFunc(ctx, serviceAccountId)
+}
+
+func (m *MockClientService) CreateServiceAccount(ctx context.Context, serviceAccountRequest ServiceAccountRequest) (*ServiceAccountResponse, *v2.RateLimitDescription, error) {
+	return m.CreateServiceAccountFunc(ctx, serviceAccountRequest)
+}
+
+func (m *MockClientService) DeleteServiceAccount(ctx context.Context, serviceAccountId string) (*v2.RateLimitDescription, error) {
+	return m.DeleteServiceAccountFunc(ctx, serviceAccountId)
+}
+
+func (m *MockClientService) UpdateServiceAccount(ctx context.Context, serviceAccountId string, serviceAccountRequest ServiceAccountRequest) (*ServiceAccountResponse, *v2.RateLimitDescription, error) {
+	return m.UpdateServiceAccountFunc(ctx, serviceAccountId, serviceAccountRequest)
+}
+
 func (m *MockClientService) GetRoles(ctx context.Context, pageToken *string) ([]*RoleResponse, *string, *v2.RateLimitDescription, error) {
 	return m.GetRolesFunc(ctx, pageToken)
 }
@@ -46,6 +100,18 @@ func (m *MockClientService) GetRole(ctx context.Context, roleId string) (*RoleRe
 	return m.GetRoleFunc(ctx, roleId)
 }
 
+func (m *MockClientService) CreateRole(ctx context.Context, roleRequest RoleRequest) (*RoleResponse, *v2.RateLimitDescription, error) {
+	return m.CreateRoleFunc(ctx, roleRequest)
+}
+
+func (m *MockClientService) DeleteRole(ctx context.Context, roleId string) (*v2.RateLimitDescription, error) {
+	return m.DeleteRoleFunc(ctx, roleId)
+}
+
+func (m *MockClientService) UpdateRole(ctx context.Context, roleId string, roleRequest RoleRequest) (*RoleResponse, *v2.RateLimitDescription, error) {
+	return m.UpdateRoleFunc(ctx, roleId, roleRequest)
+}
+
 func (m *MockClientService) AssignRoleToUser(ctx context.Context, roleId string, userId string) (*RoleResponse, *v2.RateLimitDescription, error) {
 	return m.AssignRoleToUserFunc(ctx, roleId, userId)
 }
@@ -53,3 +119,27 @@ func (m *MockClientService) AssignRoleToUser(ctx context.Context, roleId string,
 func (m *MockClientService) RemoveRoleFromUser(ctx context.Context, roleId string, userId string) (*v2.RateLimitDescription, error) {
 	return m.RemoveRoleFromUserFunc(ctx, roleId, userId)
 }
+
+func (m *MockClientService) GetPersonalAccessKeys(ctx context.Context) ([]*AccessKeyResponse, *v2.RateLimitDescription, error) {
+	return m.GetPersonalAccessKeysFunc(ctx)
+}
+
+func (m *MockClientService) GetAccessKeys(ctx context.Context, pageToken *string) ([]*AccessKeyResponse, *string, *v2.RateLimitDescription, error) {
+	return m.GetAccessKeysFunc(ctx, pageToken)
+}
+
+func (m *MockClientService) CreateServiceAccountAccessKey(ctx context.Context, serviceAccountId string, label string) (*AccessKeyCreateResponse, *v2.RateLimitDescription, error) {
+	return m.CreateServiceAccountAccessKeyFunc(ctx, serviceAccountId, label)
+}
+
+func (m *MockClientService) UpdateAccessKey(ctx context.Context, accessKeyId string, accessKeyRequest AccessKeyUpdateRequest) (*AccessKeyResponse, *v2.RateLimitDescription, error) {
+	return m.UpdateAccessKeyFunc(ctx, accessKeyId, accessKeyRequest)
+}
+
+func (m *MockClientService) DeleteAccessKey(ctx context.Context, accessKeyId string) (*v2.RateLimitDescription, error) {
+	return m.DeleteAccessKeyFunc(ctx, accessKeyId)
+}
+
+func (m *MockClientService) GetAccountOwner(ctx context.Context) (string, *v2.RateLimitDescription, error) {
+	return m.GetAccountOwnerFunc(ctx)
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+const (
+	// APIBaseURLAuto makes the client detect the deployment of the account.
+	APIBaseURLAuto = "auto"
+
+	defaultAPIBaseURL    = "https://api.sumologic.com"
+	defaultDeployment    = "us1"
+	sumoLogicDomain      = "sumologic.com"
+	deploymentProbeLimit = 1
+)
+
+// deploymentAPIBaseURLs maps the Sumo Logic deployment codes to their API base URL.
+// API Doc: https://help.sumologic.com/docs/api/getting-started/#sumo-logic-endpoints-by-deployment-and-firewall-security
+var deploymentAPIBaseURLs = map[string]string{
+	"au":  "https://api.au.sumologic.com",
+	"ca":  "https://api.ca.sumologic.com",
+	"de":  "https://api.de.sumologic.com",
+	"eu":  "https://api.eu.sumologic.com",
+	"fed": "https://api.fed.sumologic.com",
+	"in":  "https://api.in.sumologic.com",
+	"jp":  "https://api.jp.sumologic.com",
+	"kr":  "https://api.kr.sumologic.com",
+	"us1": defaultAPIBaseURL,
+	"us2": "https://api.us2.sumologic.com",
+}
+
+// IsValidAPIBaseURL reports whether the value is "auto", a deployment code or an absolute URL.
+func IsValidAPIBaseURL(apiBaseURL string) bool {
+	if _, _, err := resolveAPIBaseURL(apiBaseURL); err != nil {
+		return false
+	}
+	return true
+}
+
+// resolveAPIBaseURL returns the API base URL for the configured value and whether the deployment must be detected.
+func resolveAPIBaseURL(apiBaseURL string) (string, bool, error) {
+	value := strings.ToLower(strings.TrimSpace(apiBaseURL))
+	if value == APIBaseURLAuto {
+		return defaultAPIBaseURL, true, nil
+	}
+
+	if baseURL, ok := deploymentAPIBaseURLs[value]; ok {
+		return baseURL, false, nil
+	}
+
+	u, err := url.Parse(strings.TrimSpace(apiBaseURL))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", false, fmt.Errorf("invalid API base URL %q: expected %q, a deployment code or an absolute URL", apiBaseURL, APIBaseURLAuto)
+	}
+
+	return strings.TrimSpace(apiBaseURL), false, nil
+}
+
+// deploymentFromHost returns the deployment code of a Sumo Logic API host, e.g. "eu" for api.eu.sumologic.com.
+func deploymentFromHost(host string) string {
+	labels := strings.Split(strings.TrimSuffix(host, "."+sumoLogicDomain), ".")
+	if len(labels) == 2 && labels[0] == "api" {
+		return labels[1]
+	}
+	return defaultDeployment
+}
+
+// deploymentBaseURL extracts the API base URL from a deployment redirect location.
+// Only HTTPS Sumo Logic hosts are accepted, so the credentials are never sent elsewhere.
+func deploymentBaseURL(location string) (*url.URL, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment redirect location %q: %w", location, err)
+	}
+
+	if u.Scheme != "https" || !strings.HasSuffix(u.Hostname(), "."+sumoLogicDomain) {
+		return nil, fmt.Errorf("refusing to follow deployment redirect to %q: not a Sumo Logic API endpoint", location)
+	}
+
+	return &url.URL{Scheme: u.Scheme, Host: u.Host}, nil
+}
+
+// detectDeployment probes the default endpoint and pins the API base URL of the deployment it redirects to.
+// The redirect is followed once; any other error is left for the validation to report.
+func (c *Client) detectDeployment(ctx context.Context) error {
+	logger := ctxzap.Extract(ctx)
+
+	path := "/api/{{.apiVersion}}/users"
+	pathParameters := map[string]string{"apiVersion": apiVersion}
+
+	pageSize := uint(deploymentProbeLimit)
+	url, err := c.constructURL(path, pathParameters, nil, nil, &pageSize)
+	if err != nil {
+		return fmt.Errorf("error generating deployment probe URL: %w", err)
+	}
+
+	var response ApiResponse[UserResponse]
+	_, err = c.get(ctx, url, &response)
+
+	var redirectErr *DeploymentRedirectError
+	if errors.As(err, &redirectErr) {
+		baseURL, err := deploymentBaseURL(redirectErr.Location)
+		if err != nil {
+			return err
+		}
+		c.apiBaseURL = baseURL
+	} else if err != nil {
+		logger.Warn(
+			"baton-sumo-logic: deployment probe failed, using the default deployment",
+			zap.String("api_base_url", c.apiBaseURL.String()),
+			zap.Error(err),
+		)
+	}
+
+	logger.Info(
+		"baton-sumo-logic: using Sumo Logic deployment",
+		zap.String("deployment", deploymentFromHost(c.apiBaseURL.Hostname())),
+		zap.String("api_base_url", c.apiBaseURL.String()),
+	)
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAPIBaseURL(t *testing.T) {
+	baseURL, detect, err := resolveAPIBaseURL("auto")
+	require.NoError(t, err)
+	require.True(t, detect)
+	require.Equal(t, defaultAPIBaseURL, baseURL)
+
+	baseURL, detect, err = resolveAPIBaseURL("EU")
+	require.NoError(t, err)
+	require.False(t, detect)
+	require.Equal(t, "https://api.eu.sumologic.com", baseURL)
+
+	baseURL, _, err = resolveAPIBaseURL("https://api.us2.sumologic.com")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.us2.sumologic.com", baseURL)
+
+	_, _, err = resolveAPIBaseURL("mars")
+	require.Error(t, err)
+}
+
+func TestDeploymentBaseURL(t *testing.T) {
+	baseURL, err := deploymentBaseURL("https://api.eu.sumologic.com/api/v1/users?limit=1")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.eu.sumologic.com", baseURL.String())
+	require.Equal(t, "eu", deploymentFromHost(baseURL.Hostname()))
+	require.Equal(t, "us1", deploymentFromHost("api.sumologic.com"))
+
+	_, err = deploymentBaseURL("https://evil.example.com/api/v1/users")
+	require.Error(t, err)
+
+	_, err = deploymentBaseURL("http://api.eu.sumologic.com/api/v1/users")
+	require.Error(t, err)
+}
+
+func TestDetectDeployment(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/users", r.URL.Path)
+		http.Redirect(w, r, "https://api.eu.sumologic.com"+r.URL.RequestURI(), http.StatusMovedPermanently)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ctx, server.URL, "access-id", "access-key", RolesAPIVersionV2)
+	require.NoError(t, err)
+
+	require.NoError(t, client.detectDeployment(ctx))
+	require.Equal(t, "https://api.eu.sumologic.com", client.apiBaseURL.String())
+}
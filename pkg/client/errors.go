@@ -0,0 +1,159 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// DeploymentRedirectError is returned when Sumo Logic redirects a request to another deployment,
+// which happens when the API base URL does not match the deployment of the account.
+type DeploymentRedirectError struct {
+	StatusCode int
+	// Location is the URL of the deployment the request was redirected to.
+	Location string
+}
+
+func (e *DeploymentRedirectError) Error() string {
+	return fmt.Sprintf("request was redirected with status %d to %s: the API base URL does not match the account deployment", e.StatusCode, e.Location)
+}
+
+func isRedirect(statusCode int) bool {
+	switch statusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	default:
+		return false
+	}
+}
+
+// errorCodeSuffixes maps the reason part of Sumo Logic error codes (e.g. "not_found" in "user:not_found") to gRPC codes.
+var errorCodeSuffixes = map[string]codes.Code{
+	"not_found":                codes.NotFound,
+	"unauthorized":             codes.Unauthenticated,
+	"unauthenticated":          codes.Unauthenticated,
+	"invalid_credentials":      codes.Unauthenticated,
+	"forbidden":                codes.PermissionDenied,
+	"permission_denied":        codes.PermissionDenied,
+	"insufficient_permissions": codes.PermissionDenied,
+	"already_exists":           codes.AlreadyExists,
+	"duplicate":                codes.AlreadyExists,
+	"conflict":                 codes.AlreadyExists,
+	"bad_request":              codes.InvalidArgument,
+	"rate_limit_exceeded":      codes.ResourceExhausted,
+	"too_many_requests":        codes.ResourceExhausted,
+}
+
+// grpcCodeFromErrorCode translates a Sumo Logic error code into a gRPC code.
+func grpcCodeFromErrorCode(errorCode string) (codes.Code, bool) {
+	if errorCode == "" {
+		return codes.Unknown, false
+	}
+
+	reason := errorCode
+	if i := strings.LastIndex(errorCode, ":"); i >= 0 {
+		reason = errorCode[i+1:]
+	}
+
+	if code, ok := errorCodeSuffixes[reason]; ok {
+		return code, true
+	}
+	if strings.HasPrefix(reason, "invalid") {
+		return codes.InvalidArgument, true
+	}
+
+	return codes.Unknown, false
+}
+
+// grpcCodeFromStatus translates an HTTP status code into a gRPC code.
+func grpcCodeFromStatus(statusCode int) codes.Code {
+	switch {
+	case statusCode == http.StatusBadRequest:
+		return codes.InvalidArgument
+	case statusCode == http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case statusCode == http.StatusForbidden:
+		return codes.PermissionDenied
+	case statusCode == http.StatusNotFound:
+		return codes.NotFound
+	case statusCode == http.StatusRequestTimeout:
+		return codes.DeadlineExceeded
+	case statusCode == http.StatusConflict:
+		return codes.AlreadyExists
+	case statusCode == http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case statusCode >= http.StatusInternalServerError:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+// newStatusError builds a gRPC status error for a failed API call.
+// The Sumo Logic error code takes precedence over the HTTP status, and is kept with the request ID and target as details.
+func newStatusError(statusCode int, errorResponse *ErrorResponse, rateLimit *v2.RateLimitDescription, cause error) error {
+	errorCode := errorResponse.ErrorCode()
+
+	code, ok := grpcCodeFromErrorCode(errorCode)
+	if !ok {
+		code = grpcCodeFromStatus(statusCode)
+	}
+
+	msg := fmt.Sprintf("request failed with status %d: %v", statusCode, cause)
+	if errorCode != "" {
+		msg = fmt.Sprintf("request failed with status %d: %s", statusCode, errorResponse.Message())
+	}
+
+	st := status.New(code, msg)
+
+	metadata := map[string]string{
+		"http_status": strconv.Itoa(statusCode),
+	}
+	if errorResponse.ID != "" {
+		metadata["request_id"] = errorResponse.ID
+	}
+	if errorResponse.Target != nil {
+		metadata["target"] = *errorResponse.Target
+	}
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Reason:   errorCode,
+			Domain:   sumoLogicDomain,
+			Metadata: metadata,
+		},
+	}
+	// The rate limit description lets the SDK wait until the limit resets before retrying.
+	if rateLimit != nil && rateLimit.ResetAt != nil {
+		details = append(details, rateLimit)
+	}
+
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+// ErrorCode returns the Sumo Logic error code attached to an error returned by the client, if any.
+func ErrorCode(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == sumoLogicDomain {
+			return info.GetReason()
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(context.Background(), server.URL, "access-id", "access-key", RolesAPIVersionV2)
+	require.NoError(t, err)
+
+	return client
+}
+
+func writeErrorResponse(w http.ResponseWriter, statusCode int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write([]byte(body))
+}
+
+func TestErrorMapping(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should map Sumo Logic error codes", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			writeErrorResponse(w, http.StatusNotFound, `{"id":"REQ-1","errors":[{"code":"user:not_found","message":"User not found."}]}`)
+		})
+
+		_, _, err := client.getUserByID(ctx, "missing")
+		require.Error(t, err)
+		require.Equal(t, codes.NotFound, status.Code(err))
+		require.Equal(t, "user:not_found", ErrorCode(err))
+		require.Contains(t, err.Error(), "User not found.")
+	})
+
+	t.Run("should prefer the Sumo Logic error code over the HTTP status", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			writeErrorResponse(w, http.StatusBadRequest, `{"id":"REQ-2","errors":[{"code":"user:already_exists","message":"User already exists."}]}`)
+		})
+
+		_, _, err := client.createUser(ctx, UserRequest{Email: "user@example.com"})
+		require.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("should fall back to the HTTP status", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			writeErrorResponse(w, http.StatusForbidden, `{"id":"REQ-3","errors":[{"code":"some:unknown_code","message":"Nope."}]}`)
+		})
+
+		_, _, err := client.getRole(ctx, "role")
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+		require.Equal(t, "some:unknown_code", ErrorCode(err))
+	})
+
+	t.Run("should report rate limits as resource exhausted with the rate limit details", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			writeErrorResponse(w, http.StatusTooManyRequests, `{"id":"REQ-4","errors":[{"code":"rate_limit_exceeded","message":"Rate limit exceeded."}]}`)
+		})
+
+		_, _, _, err := client.getUsers(ctx, nil)
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+		require.Equal(t, "rate_limit_exceeded", ErrorCode(err))
+
+		var rateLimit *v2.RateLimitDescription
+		for _, detail := range status.Convert(err).Details() {
+			if d, ok := detail.(*v2.RateLimitDescription); ok {
+				rateLimit = d
+			}
+		}
+		require.NotNil(t, rateLimit)
+		require.Equal(t, v2.RateLimitDescription_STATUS_OVERLIMIT, rateLimit.Status)
+	})
+}
@@ -53,3 +53,60 @@ func (c *Client) constructURL(path string, pathParams map[string]string, queryPa
 
 	return &u, nil
 }
+
+// roleRequestPayload builds the JSON body shared by the create and update role endpoints.
+// The v1 Roles API takes a single filter predicate, while v2 takes separate data access filters.
+func roleRequestPayload(rolesAPIVersion string, roleRequest RoleRequest) map[string]interface{} {
+	payload := map[string]interface{}{
+		"name":         roleRequest.Name,
+		"description":  roleRequest.Description,
+		"users":        roleRequest.Users,
+		"capabilities": roleRequest.Capabilities,
+	}
+	// When omitted Sumo Logic defaults to autofilling missing capability dependencies.
+	if roleRequest.AutofillDependencies != nil {
+		payload["autofillDependencies"] = *roleRequest.AutofillDependencies
+	}
+
+	if rolesAPIVersion != RolesAPIVersionV2 {
+		payload["filterPredicate"] = roleRequest.FilterPredicate
+		return payload
+	}
+
+	filters := RoleDataFilters{}
+	if roleRequest.DataFilters != nil {
+		filters = *roleRequest.DataFilters
+	}
+	payload["logAnalyticsFilter"] = roleRequest.FilterPredicate
+	if filters.LogAnalyticsFilter != nil {
+		payload["logAnalyticsFilter"] = *filters.LogAnalyticsFilter
+	}
+	payload["auditDataFilter"] = ""
+	if filters.AuditDataFilter != nil {
+		payload["auditDataFilter"] = *filters.AuditDataFilter
+	}
+	payload["securityDataFilter"] = ""
+	if filters.SecurityDataFilter != nil {
+		payload["securityDataFilter"] = *filters.SecurityDataFilter
+	}
+	payload["selectionType"] = "All"
+	if filters.SelectionType != nil {
+		payload["selectionType"] = *filters.SelectionType
+	}
+	if filters.SelectedViews != nil {
+		payload["selectedViews"] = *filters.SelectedViews
+	}
+
+	return payload
+}
+
+// toRoleResponse converts a decoded role into the common role model.
+// Data access filters are only kept when the v2 Roles API is in use.
+func (c *Client) toRoleResponse(r *RoleV2Response) *RoleResponse {
+	role := r.RoleResponse
+	if c.rolesAPIVersion == RolesAPIVersionV2 {
+		filters := r.RoleDataFilters
+		role.DataFilters = &filters
+	}
+	return &role
+}
@@ -2,13 +2,26 @@ package client
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
+// ErrorResponse is the error body returned by the Sumo Logic API.
+// API Doc: https://api.sumologic.com/docs/#section/Getting-Started/Errors
 type ErrorResponse struct {
-	Code   string  `json:"code"`
-	Msg    string  `json:"message"`
-	Target *string `json:"target,omitempty"`
+	// Identifier of the request, useful when contacting Sumo Logic support.
+	ID     string        `json:"id,omitempty"`
+	Errors []ErrorDetail `json:"errors,omitempty"`
+	Code   string        `json:"code"`
+	Msg    string        `json:"message"`
+	Target *string       `json:"target,omitempty"`
+}
+
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Additional information about the error, e.g. the field that failed validation.
+	Meta map[string]interface{} `json:"meta,omitempty"`
 }
 
 // Implement the required method for the interface.
@@ -17,7 +30,25 @@ func (e *ErrorResponse) Message() string {
 	if e.Target != nil {
 		target = *e.Target
 	}
-	return fmt.Sprintf("code: %s, message: %s, target: %s", e.Code, e.Msg, target)
+
+	msg := e.Msg
+	if msg == "" && len(e.Errors) > 0 {
+		messages := make([]string, 0, len(e.Errors))
+		for _, detail := range e.Errors {
+			messages = append(messages, detail.Message)
+		}
+		msg = strings.Join(messages, "; ")
+	}
+
+	return fmt.Sprintf("code: %s, message: %s, target: %s", e.ErrorCode(), msg, target)
+}
+
+// ErrorCode returns the Sumo Logic error code, e.g. "user:not_found".
+func (e *ErrorResponse) ErrorCode() string {
+	if e.Code == "" && len(e.Errors) > 0 {
+		return e.Errors[0].Code
+	}
+	return e.Code
 }
 
 type ApiResponse[T any] struct {
@@ -84,6 +115,65 @@ type RoleResponse struct {
 	// This has the value true if the role is defined by the system.
 	// If a role is defined by the system, it cannot be deleted or modified.
 	SystemDefined *bool `json:"systemDefined,omitempty"`
+	// Data access filters of the role, only set when the role was read from the v2 Roles API.
+	DataFilters *RoleDataFilters `json:"-"`
+}
+
+type RoleSelectedView struct {
+	ViewName   string `json:"viewName"`
+	ViewFilter string `json:"viewFilter,omitempty"`
+}
+
+// RoleDataFilters holds the data access filters exposed by the v2 Roles API.
+type RoleDataFilters struct {
+	// A search filter which would be applied on logs, partitions and scheduled views.
+	LogAnalyticsFilter *string `json:"logAnalyticsFilter,omitempty"`
+	// A search filter which would be applied on audit index logs.
+	AuditDataFilter *string `json:"auditDataFilter,omitempty"`
+	// A search filter which would be applied on Cloud SIEM data.
+	SecurityDataFilter *string `json:"securityDataFilter,omitempty"`
+	// Describes the scheduled views the role can access: All, Allow or Deny.
+	SelectionType *string `json:"selectionType,omitempty"`
+	// List of scheduled views allowed or denied depending on the selection type.
+	SelectedViews *[]RoleSelectedView `json:"selectedViews,omitempty"`
+}
+
+// RoleV2Response is a role returned by the v2 Roles API.
+// It is a superset of the v1 role model, so both versions are decoded into it.
+type RoleV2Response struct {
+	RoleResponse
+	RoleDataFilters
+}
+
+type AccessKeyResponse struct {
+	// Identifier of the access key, also known as the access ID.
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	// An array of domains for which the access key is valid.
+	CorsHeaders []string `json:"corsHeaders,omitempty"`
+	// Indicates whether the access key is disabled or not.
+	Disabled bool `json:"disabled"`
+	// Creation timestamp in UTC in RFC3339 format <date-time> (YYYY-MM-DDTHH:MM:SSZ).
+	CreatedAt time.Time `json:"createdAt"`
+	// Identifier of the user who created the access key.
+	// The keys created for a service account are expected to report its ID, which Sumo Logic does not document.
+	CreatedBy string `json:"createdBy"`
+	// Last used timestamp in UTC in RFC3339 format <date-time> (YYYY-MM-DDTHH:MM:SSZ).
+	LastUsed *time.Time `json:"lastUsed,omitempty"`
+}
+
+// AccessKeyCreateResponse is an access key as returned on creation, the only time the secret key is readable.
+type AccessKeyCreateResponse struct {
+	AccessKeyResponse
+	// The secret access key.
+	Key string `json:"key"`
+}
+
+type AccessKeyUpdateRequest struct {
+	// Indicates whether the access key is disabled or not.
+	Disabled bool `json:"disabled"`
+	// An array of domains for which the access key is valid.
+	CorsHeaders []string `json:"corsHeaders,omitempty"`
 }
 
 type UserRequest struct {
@@ -92,3 +182,32 @@ type UserRequest struct {
 	Email     string   `json:"email"`
 	RoleIDs   []string `json:"roleIds"`
 }
+
+// UserUpdateRequest replaces the attributes of a user, every field must be set.
+type UserUpdateRequest struct {
+	FirstName string   `json:"firstName"`
+	LastName  string   `json:"lastName"`
+	IsActive  bool     `json:"isActive"`
+	RoleIDs   []string `json:"roleIds"`
+}
+
+type ServiceAccountRequest struct {
+	Name    string   `json:"name"`
+	Email   string   `json:"email"`
+	RoleIDs []string `json:"roleIds"`
+}
+
+type RoleRequest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	FilterPredicate string `json:"filterPredicate"`
+	// List of user identifiers to assign the role to.
+	Users []string `json:"users"`
+	// List of capabilities to assign the role to.
+	Capabilities []string `json:"capabilities"`
+	// Set this to true if you want to automatically append all missing capability requirements.
+	// If set to false an error will be thrown if any capabilities are missing their dependencies.
+	AutofillDependencies *bool `json:"autofillDependencies,omitempty"`
+	// Data access filters sent to the v2 Roles API instead of the filter predicate.
+	DataFilters *RoleDataFilters `json:"-"`
+}
@@ -1,8 +1,11 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -12,6 +15,21 @@ import (
 	"go.uber.org/zap"
 )
 
+// withoutCacheKey marks a context whose GET requests must not be answered from the HTTP cache.
+type withoutCacheKey struct{}
+
+// WithoutCache returns a context whose GET requests reach the API instead of the HTTP cache.
+// Provisioning uses it to read the current state of an object before or after changing it,
+// since a cached response may be as old as the last sync.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutCacheKey{}, true)
+}
+
+func skipCache(ctx context.Context) bool {
+	skip, _ := ctx.Value(withoutCacheKey{}).(bool)
+	return skip
+}
+
 // get performs a GET request to the API.
 func (c *Client) get(
 	ctx context.Context,
@@ -38,12 +56,18 @@ func (c *Client) post(
 	*v2.RateLimitDescription,
 	error,
 ) {
+	var options []uhttp.RequestOption
+	// Some POST endpoints (e.g. unlocking a user) take no request body.
+	if payload != nil {
+		options = append(options, uhttp.WithJSONBody(payload))
+	}
+
 	return c.doRequest(
 		ctx,
 		http.MethodPost,
 		url,
 		target,
-		uhttp.WithJSONBody(payload),
+		options...,
 	)
 }
 
@@ -51,15 +75,23 @@ func (c *Client) put(
 	ctx context.Context,
 	url *url.URL,
 	target interface{},
+	payload map[string]interface{},
 ) (
 	*v2.RateLimitDescription,
 	error,
 ) {
+	var options []uhttp.RequestOption
+	// Some PUT endpoints (e.g. assigning a role to a user) take no request body.
+	if payload != nil {
+		options = append(options, uhttp.WithJSONBody(payload))
+	}
+
 	return c.doRequest(
 		ctx,
 		http.MethodPut,
 		url,
 		target,
+		options...,
 	)
 }
 
@@ -80,7 +112,7 @@ func (c *Client) delete(
 }
 
 // doRequest is a helper function that creates a request and executes it.
-// It also handles the rate limiting and error response.
+// It also handles the rate limiting and error response, failed API calls are returned as gRPC status errors.
 // If the target is not nil, it will unmarshal the response into the target.
 func (c *Client) doRequest(
 	ctx context.Context,
@@ -116,20 +148,73 @@ func (c *Client) doRequest(
 	}
 
 	var ratelimitData v2.RateLimitDescription
+	errorResponse := &ErrorResponse{}
 	doOptions := []uhttp.DoOption{
 		uhttp.WithRatelimitData(&ratelimitData),
-		uhttp.WithErrorResponse(&ErrorResponse{}),
+		uhttp.WithErrorResponse(errorResponse),
 	}
 	// If the target is not nil, we want to unmarshal the response into the target.
 	if target != nil {
 		doOptions = append(doOptions, uhttp.WithJSONResponse(target))
 	}
 
-	response, err := c.httpClient.Do(request, doOptions...)
+	var response *http.Response
+	if method == http.MethodGet && skipCache(ctx) {
+		response, err = c.doWithoutCache(request, doOptions...)
+	} else {
+		response, err = c.httpClient.Do(request, doOptions...)
+	}
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if response != nil && isRedirect(response.StatusCode) {
+		return &ratelimitData, &DeploymentRedirectError{
+			StatusCode: response.StatusCode,
+			Location:   response.Header.Get("Location"),
+		}
+	}
+	// Translate API errors into gRPC status errors so callers can branch on the status code.
+	if err != nil && response != nil && response.StatusCode >= http.StatusBadRequest {
+		return &ratelimitData, newStatusError(response.StatusCode, errorResponse, &ratelimitData, err)
+	}
 	if err != nil {
 		return &ratelimitData, fmt.Errorf("request failed: %w", err)
 	}
-	defer response.Body.Close()
 
 	return &ratelimitData, nil
 }
+
+// doWithoutCache executes the request with the underlying HTTP client, so the response is neither read from
+// nor stored in the SDK cache, and applies the options the same way the SDK does.
+// Non-2xx responses return an error, doRequest turns them into gRPC status errors.
+func (c *Client) doWithoutCache(request *http.Request, options ...uhttp.DoOption) (*http.Response, error) {
+	response, err := c.httpClient.HttpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return response, fmt.Errorf("failed to read response body: %w", err)
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	wrapper := uhttp.WrapperResponse{
+		Header:     response.Header,
+		Body:       body,
+		Status:     response.Status,
+		StatusCode: response.StatusCode,
+	}
+	var errs []error
+	for _, option := range options {
+		if err := option(&wrapper); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		errs = append(errs, fmt.Errorf("unexpected status code: %d", response.StatusCode))
+	}
+
+	return response, errors.Join(errs...)
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithoutCache(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"user-1","isActive":true}`))
+	})
+
+	_, _, err := client.getUserByID(ctx, "user-1")
+	require.NoError(t, err)
+	_, _, err = client.getUserByID(ctx, "user-1")
+	require.NoError(t, err)
+	require.Equal(t, int32(1), requests.Load(), "the second read should be answered from the cache")
+
+	_, _, err = client.getUserByID(WithoutCache(ctx), "user-1")
+	require.NoError(t, err)
+	require.Equal(t, int32(2), requests.Load(), "the read without cache should reach the API")
+
+	_, _, err = client.getUserByID(ctx, "user-1")
+	require.NoError(t, err)
+	require.Equal(t, int32(2), requests.Load(), "the read without cache should leave the cached response in place")
+}
+
+func TestWithoutCacheErrors(t *testing.T) {
+	ctx := WithoutCache(context.Background())
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeErrorResponse(w, http.StatusNotFound, `{"id":"REQ-1","errors":[{"code":"user:not_found","message":"User not found."}]}`)
+	})
+
+	_, _, err := client.getUserByID(ctx, "user-1")
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Equal(t, "user:not_found", ErrorCode(err))
+}
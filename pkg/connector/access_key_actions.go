@@ -0,0 +1,229 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/conductorone/baton-sdk/pb/c1/config/v1"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	disableAccessKeyAction = "disable_access_key"
+	enableAccessKeyAction  = "enable_access_key"
+	retireAccessKeyAction  = "retire_access_key"
+
+	accessKeyIDArgument = "access_key_id"
+	replacedByArgument  = "replaced_by"
+)
+
+func (m *actionManager) registerAccessKeyActions() {
+	accessKeyID := stringActionField(accessKeyIDArgument, "Access Key ID", "The access ID of the key.", true)
+	returnTypes := []*v1.Field{
+		boolActionField("success", "Success", "Whether the key was updated."),
+		boolActionField("disabled", "Disabled", "Whether the key is now disabled."),
+	}
+
+	m.register(&v2.BatonActionSchema{
+		Name:        disableAccessKeyAction,
+		DisplayName: "Disable Access Key",
+		Description: "Disable a Sumo Logic access key, it can no longer authenticate API requests.",
+		Arguments:   []*v1.Field{accessKeyID},
+		ReturnTypes: returnTypes,
+	}, func(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+		return m.setAccessKeyDisabled(ctx, args, true)
+	})
+
+	m.register(&v2.BatonActionSchema{
+		Name:        enableAccessKeyAction,
+		DisplayName: "Enable Access Key",
+		Description: "Enable a disabled Sumo Logic access key.",
+		Arguments:   []*v1.Field{accessKeyID},
+		ReturnTypes: returnTypes,
+	}, func(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+		return m.setAccessKeyDisabled(ctx, args, false)
+	})
+
+	m.register(&v2.BatonActionSchema{
+		Name:        retireAccessKeyAction,
+		DisplayName: "Retire Access Key",
+		Description: "Disable then delete an access key replaced by a credential rotation, once the rotation overlap has ended.",
+		Arguments: []*v1.Field{
+			stringActionField(accessKeyIDArgument, "Access Key ID", "The access ID of the key to retire.", true),
+			stringActionField(replacedByArgument, "Replaced By", "The access ID of the key that replaced it, as returned by the rotation.", true),
+		},
+		ReturnTypes: []*v1.Field{
+			boolActionField("success", "Success", "Whether the key was retired."),
+		},
+	}, m.retireReplacedAccessKey)
+}
+
+func (m *actionManager) setAccessKeyDisabled(ctx context.Context, args *structpb.Struct, disabled bool) (*structpb.Struct, annotations.Annotations, error) {
+	accessKeyID, err := stringArgument(args, accessKeyIDArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if disabled && isConnectorAccessKey(m.apiAccessID, accessKeyID) {
+		return nil, nil, status.Errorf(
+			codes.FailedPrecondition,
+			"baton-sumo-logic: refusing to disable access key %s, the connector is authenticated with it",
+			accessKeyID,
+		)
+	}
+
+	// The update replaces the allowed CORS domains, so the current ones are read and sent back.
+	current, outputAnnotations, err := findAccessKey(client.WithoutCache(ctx), m.service, accessKeyID)
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	accessKey, rateLimit, err := m.service.UpdateAccessKey(ctx, accessKeyID, client.AccessKeyUpdateRequest{
+		Disabled:    disabled,
+		CorsHeaders: current.CorsHeaders,
+	})
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to update access key: %w", err)
+	}
+
+	if accessKey != nil && accessKey.ID != "" {
+		disabled = accessKey.Disabled
+	}
+
+	response, err := actionResponse(map[string]interface{}{
+		accessKeyIDArgument: accessKeyID,
+		"disabled":          disabled,
+	})
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	return response, outputAnnotations, nil
+}
+
+// retireReplacedAccessKey retires a key replaced by a rotation. Only the key given is retired, and only once the key
+// replacing it, created by the same account, is older than the rotation overlap.
+func (m *actionManager) retireReplacedAccessKey(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	accessKeyID, err := stringArgument(args, accessKeyIDArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+	replacedBy, err := stringArgument(args, replacedByArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if isConnectorAccessKey(m.apiAccessID, accessKeyID) {
+		return nil, nil, status.Errorf(
+			codes.FailedPrecondition,
+			"baton-sumo-logic: refusing to retire access key %s, the connector is authenticated with it",
+			accessKeyID,
+		)
+	}
+	if strings.EqualFold(accessKeyID, replacedBy) {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: an access key cannot replace itself")
+	}
+
+	accessKey, outputAnnotations, err := findAccessKey(client.WithoutCache(ctx), m.service, accessKeyID)
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+	replacement, replacementAnnotations, err := findAccessKey(client.WithoutCache(ctx), m.service, replacedBy)
+	outputAnnotations.Merge(replacementAnnotations...)
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	if replacement.CreatedBy != accessKey.CreatedBy || !replacement.CreatedAt.After(accessKey.CreatedAt) {
+		return nil, outputAnnotations, status.Errorf(
+			codes.FailedPrecondition,
+			"baton-sumo-logic: access key %s was not replaced by %s, it must be a newer key of the same account",
+			accessKeyID,
+			replacedBy,
+		)
+	}
+	if overlapEnd := replacement.CreatedAt.Add(m.accessKeyRotationOverlap); time.Now().Before(overlapEnd) {
+		return nil, outputAnnotations, status.Errorf(
+			codes.FailedPrecondition,
+			"baton-sumo-logic: access key %s can be retired once the rotation overlap ends at %s",
+			accessKeyID,
+			overlapEnd.UTC().Format(time.RFC3339),
+		)
+	}
+
+	retireAnnotations, err := retireAccessKey(ctx, m.service, accessKey)
+	outputAnnotations.Merge(retireAnnotations...)
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	response, err := actionResponse(map[string]interface{}{
+		accessKeyIDArgument: accessKeyID,
+		replacedByArgument:  replacedBy,
+	})
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	return response, outputAnnotations, nil
+}
+
+// retireAccessKey disables an access key before deleting it, so it stops authenticating even if the delete fails.
+// The update replaces the allowed CORS domains, so the current ones are sent back.
+func retireAccessKey(ctx context.Context, service client.ClientService, accessKey *client.AccessKeyResponse) (annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	_, rateLimit, err := service.UpdateAccessKey(ctx, accessKey.ID, client.AccessKeyUpdateRequest{
+		Disabled:    true,
+		CorsHeaders: accessKey.CorsHeaders,
+	})
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if status.Code(err) == codes.NotFound {
+		return outputAnnotations, nil
+	}
+	if err != nil {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to disable access key: %w", err)
+	}
+
+	rateLimit, err = service.DeleteAccessKey(ctx, accessKey.ID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to delete access key: %w", err)
+	}
+
+	return outputAnnotations, nil
+}
+
+// findAccessKey returns the access key with the given ID.
+// The API has no endpoint to get a single access key, so the keys are listed until it is found.
+func findAccessKey(ctx context.Context, service client.ClientService, accessKeyID string) (*client.AccessKeyResponse, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	var pageToken *string
+	for {
+		accessKeys, nextPageToken, rateLimit, err := service.GetAccessKeys(ctx, pageToken)
+		outputAnnotations.WithRateLimiting(rateLimit)
+		if err != nil {
+			return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to list access keys: %w", err)
+		}
+
+		for _, accessKey := range accessKeys {
+			if accessKey.ID == accessKeyID {
+				return accessKey, outputAnnotations, nil
+			}
+		}
+
+		if nextPageToken == nil || *nextPageToken == "" {
+			return nil, outputAnnotations, status.Errorf(codes.NotFound, "baton-sumo-logic: access key %s not found", accessKeyID)
+		}
+		pageToken = nextPageToken
+	}
+}
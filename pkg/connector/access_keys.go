@@ -0,0 +1,241 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const accessKeyOwnerEntitlement = "owner"
+
+type accessKeyBuilder struct {
+	service                client.ClientService
+	apiAccessID            string
+	includeServiceAccounts bool
+
+	// serviceAccountIDs holds the IDs of the service accounts, listed once per sync to find the owner of each key.
+	mu                sync.Mutex
+	serviceAccountIDs map[string]struct{}
+}
+
+func (o *accessKeyBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return accessKeyResourceType
+}
+
+// List returns the access keys of every user and service account as secret resources.
+// Access keys only record the ID of their owner, so service accounts are listed to tell them apart from users.
+func (o *accessKeyBuilder) List(ctx context.Context, _ *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	// A new sync starts by listing access keys again, so service accounts from a previous sync are dropped.
+	if pToken == nil || pToken.Token == "" {
+		o.resetServiceAccountIDs()
+	}
+
+	accessKeys, nextPageToken, rateLimit, err := o.service.GetAccessKeys(ctx, parsePageToken(pToken))
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, "", outputAnnotations, fmt.Errorf("failed to list access keys: %w", err)
+	}
+
+	serviceAccountIDs, serviceAccountAnnotations, err := o.listServiceAccountIDs(ctx)
+	outputAnnotations.Merge(serviceAccountAnnotations...)
+	if err != nil {
+		return nil, "", outputAnnotations, fmt.Errorf("failed to get service accounts: %w", err)
+	}
+
+	resources := make([]*v2.Resource, 0, len(accessKeys))
+	for _, accessKey := range accessKeys {
+		var owner *v2.ResourceId
+		if _, ok := serviceAccountIDs[accessKey.CreatedBy]; ok {
+			// Keys of service accounts that are not synced are left without an owner.
+			if o.includeServiceAccounts {
+				owner = &v2.ResourceId{ResourceType: serviceAccountResourceType.Id, Resource: accessKey.CreatedBy}
+			}
+		} else if accessKey.CreatedBy != "" {
+			owner = &v2.ResourceId{ResourceType: userResourceType.Id, Resource: accessKey.CreatedBy}
+		}
+
+		accessKeyResource, err := createAccessKeyResource(accessKey, owner)
+		if err != nil {
+			return nil, "", outputAnnotations, fmt.Errorf("failed to create access key resource: %w", err)
+		}
+		resources = append(resources, accessKeyResource)
+	}
+
+	return resources, createPageToken(nextPageToken), outputAnnotations, nil
+}
+
+// resetServiceAccountIDs drops the service accounts so the next page lists them again.
+func (o *accessKeyBuilder) resetServiceAccountIDs() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.serviceAccountIDs = nil
+}
+
+// listServiceAccountIDs returns the IDs of the service accounts, listing them on first use.
+func (o *accessKeyBuilder) listServiceAccountIDs(ctx context.Context) (map[string]struct{}, annotations.Annotations, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.serviceAccountIDs != nil {
+		return o.serviceAccountIDs, nil, nil
+	}
+
+	outputAnnotations := annotations.New()
+	serviceAccounts, rateLimit, err := o.service.GetServiceAccounts(ctx)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	serviceAccountIDs := make(map[string]struct{}, len(serviceAccounts))
+	for _, serviceAccount := range serviceAccounts {
+		serviceAccountIDs[serviceAccount.ID] = struct{}{}
+	}
+	o.serviceAccountIDs = serviceAccountIDs
+
+	return serviceAccountIDs, outputAnnotations, nil
+}
+
+// Entitlements returns the owner entitlement, held by the user or service account the key authenticates.
+func (o *accessKeyBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+
+	ownerOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(userResourceType, serviceAccountResourceType),
+		ent.WithDisplayName(fmt.Sprintf("%s Access Key Owner", resource.DisplayName)),
+		ent.WithDescription(fmt.Sprintf("Owns the %s access key in Sumo Logic", resource.DisplayName)),
+	}
+
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, accessKeyOwnerEntitlement, ownerOptions...))
+
+	return rv, "", nil, nil
+}
+
+// Grants links the access key to its owner, read from the secret trait so no API call is needed.
+func (o *accessKeyBuilder) Grants(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	secretTrait := &v2.SecretTrait{}
+	resourceAnnotations := annotations.Annotations(resource.Annotations)
+	ok, err := resourceAnnotations.Pick(secretTrait)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to get access key trait: %w", err)
+	}
+	if !ok || secretTrait.GetIdentityId() == nil {
+		return nil, "", nil, nil
+	}
+
+	return []*v2.Grant{grant.NewGrant(resource, accessKeyOwnerEntitlement, secretTrait.GetIdentityId())}, "", nil, nil
+}
+
+// Delete implements the ResourceDeleter interface.
+// The key the connector is authenticated with cannot be deleted.
+func (o *accessKeyBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	accessKeyID := resourceId.GetResource()
+	if len(accessKeyID) == 0 {
+		return nil, fmt.Errorf("missing resource ID")
+	}
+	l := ctxzap.Extract(ctx).With(zap.String("accessKeyID", accessKeyID))
+
+	if isConnectorAccessKey(o.apiAccessID, accessKeyID) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"baton-sumo-logic: refusing to delete access key %s, the connector is authenticated with it",
+			accessKeyID,
+		)
+	}
+
+	outputAnnotations := annotations.New()
+	rateLimit, err := o.service.DeleteAccessKey(ctx, accessKeyID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if status.Code(err) == codes.NotFound {
+		l.Info("baton-sumo-logic: delete-access-key: access key was already deleted")
+		return outputAnnotations, nil
+	}
+	if err != nil {
+		l.Error("baton-sumo-logic: delete-access-key: failed to delete access key", zap.Error(err))
+		return outputAnnotations, err
+	}
+
+	l.Info("baton-sumo-logic: delete-access-key: success")
+	return outputAnnotations, nil
+}
+
+func newAccessKeyBuilder(cclient *client.Client, apiAccessID string, includeServiceAccounts bool) *accessKeyBuilder {
+	return &accessKeyBuilder{
+		service:                client.NewClientService(cclient),
+		apiAccessID:            apiAccessID,
+		includeServiceAccounts: includeServiceAccounts,
+	}
+}
+
+// isConnectorAccessKey reports whether the access key is the one the connector is authenticated with.
+func isConnectorAccessKey(apiAccessID, accessKeyID string) bool {
+	return strings.EqualFold(apiAccessID, accessKeyID)
+}
+
+func createAccessKeyResource(accessKey *client.AccessKeyResponse, owner *v2.ResourceId) (*v2.Resource, error) {
+	displayName := accessKey.Label
+	if displayName == "" {
+		displayName = accessKey.ID
+	}
+
+	profile := map[string]interface{}{
+		"access_id":    accessKey.ID,
+		"label":        accessKey.Label,
+		"disabled":     accessKey.Disabled,
+		"created_by":   accessKey.CreatedBy,
+		"cors_headers": strings.Join(accessKey.CorsHeaders, ","),
+	}
+
+	secretTraitOptions := []rs.SecretTraitOption{
+		withSecretProfile(profile),
+		rs.WithSecretCreatedAt(accessKey.CreatedAt),
+	}
+
+	if accessKey.LastUsed != nil {
+		secretTraitOptions = append(secretTraitOptions, rs.WithSecretLastUsedAt(*accessKey.LastUsed))
+	}
+
+	if owner != nil {
+		secretTraitOptions = append(
+			secretTraitOptions,
+			rs.WithSecretCreatedByID(owner),
+			rs.WithSecretIdentityID(owner),
+		)
+	}
+
+	return rs.NewSecretResource(
+		displayName,
+		accessKeyResourceType,
+		accessKey.ID,
+		secretTraitOptions,
+	)
+}
+
+// withSecretProfile sets the profile of the secret trait, the SDK has no option for it.
+func withSecretProfile(profile map[string]interface{}) rs.SecretTraitOption {
+	return func(t *v2.SecretTrait) error {
+		p, err := structpb.NewStruct(profile)
+		if err != nil {
+			return err
+		}
+		t.Profile = p
+		return nil
+	}
+}
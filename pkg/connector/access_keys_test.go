@@ -0,0 +1,340 @@
+package connector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Helper function to create a test builder with mocks.
+func newTestAccessKeyBuilder(includeServiceAccounts bool) (*accessKeyBuilder, *client.MockClientService) {
+	mockClient := &client.Client{}
+	mockClientService := &client.MockClientService{}
+
+	builder := newAccessKeyBuilder(mockClient, "connector-key", includeServiceAccounts)
+	// Replace the service with our mock.
+	builder.service = mockClientService
+
+	return builder, mockClientService
+}
+
+func TestAccessKeysList(t *testing.T) {
+	ctx := context.Background()
+
+	lastUsed := time.Now()
+	accessKeys := []*client.AccessKeyResponse{
+		{ID: "key-1", Label: "laptop", CreatedAt: time.Now(), CreatedBy: "user-1", LastUsed: &lastUsed},
+		{ID: "key-2", Label: "pipeline", Disabled: true, CreatedAt: time.Now(), CreatedBy: "service-1"},
+	}
+
+	newMocks := func(includeServiceAccounts bool) *accessKeyBuilder {
+		accessKeyBuilder, mockClientService := newTestAccessKeyBuilder(includeServiceAccounts)
+
+		nextToken := "page-2"
+		mockClientService.GetAccessKeysFunc = func(ctx context.Context, pageToken *string) ([]*client.AccessKeyResponse, *string, *v2.RateLimitDescription, error) {
+			require.Empty(t, *pageToken)
+			return accessKeys, &nextToken, nil, nil
+		}
+		mockClientService.GetServiceAccountsFunc = func(ctx context.Context) ([]*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			return []*client.ServiceAccountResponse{{BaseAccount: client.BaseAccount{ID: "service-1"}}}, nil, nil
+		}
+
+		return accessKeyBuilder
+	}
+
+	secretTrait := func(t *testing.T, resource *v2.Resource) *v2.SecretTrait {
+		trait := &v2.SecretTrait{}
+		resourceAnnotations := annotations.Annotations(resource.Annotations)
+		ok, err := resourceAnnotations.Pick(trait)
+		require.NoError(t, err)
+		require.True(t, ok)
+		return trait
+	}
+
+	t.Run("should link each key to its user or service account", func(t *testing.T) {
+		accessKeyBuilder := newMocks(true)
+
+		resources, token, _, err := accessKeyBuilder.List(ctx, nil, &pagination.Token{})
+		require.NoError(t, err)
+		require.Equal(t, "page-2", token)
+		require.Len(t, resources, 2)
+
+		userKey := secretTrait(t, resources[0])
+		require.Equal(t, "laptop", resources[0].DisplayName)
+		require.Equal(t, userResourceType.Id, userKey.IdentityId.ResourceType)
+		require.Equal(t, "user-1", userKey.IdentityId.Resource)
+		require.NotNil(t, userKey.LastUsedAt)
+
+		serviceAccountKey := secretTrait(t, resources[1])
+		require.Equal(t, serviceAccountResourceType.Id, serviceAccountKey.IdentityId.ResourceType)
+		require.Equal(t, true, serviceAccountKey.Profile.AsMap()["disabled"])
+
+		grants, _, _, err := accessKeyBuilder.Grants(ctx, resources[1], &pagination.Token{})
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		require.Equal(t, "access_key:key-2:owner", grants[0].Entitlement.Id)
+		require.Equal(t, "service-1", grants[0].Principal.Id.Resource)
+	})
+
+	t.Run("should leave keys of unsynced service accounts without an owner", func(t *testing.T) {
+		accessKeyBuilder := newMocks(false)
+
+		resources, _, _, err := accessKeyBuilder.List(ctx, nil, &pagination.Token{})
+		require.NoError(t, err)
+		require.Nil(t, secretTrait(t, resources[1]).IdentityId)
+
+		grants, _, _, err := accessKeyBuilder.Grants(ctx, resources[1], &pagination.Token{})
+		require.NoError(t, err)
+		require.Empty(t, grants)
+	})
+
+	t.Run("should list the service accounts once per sync", func(t *testing.T) {
+		accessKeyBuilder, mockClientService := newTestAccessKeyBuilder(true)
+
+		mockClientService.GetAccessKeysFunc = func(ctx context.Context, pageToken *string) ([]*client.AccessKeyResponse, *string, *v2.RateLimitDescription, error) {
+			if *pageToken == "" {
+				nextToken := "page-2"
+				return accessKeys[:1], &nextToken, nil, nil
+			}
+			return accessKeys[1:], nil, nil, nil
+		}
+		serviceAccountCalls := 0
+		mockClientService.GetServiceAccountsFunc = func(ctx context.Context) ([]*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			serviceAccountCalls++
+			return []*client.ServiceAccountResponse{{BaseAccount: client.BaseAccount{ID: "service-1"}}}, nil, nil
+		}
+
+		for range 2 {
+			_, token, _, err := accessKeyBuilder.List(ctx, nil, &pagination.Token{})
+			require.NoError(t, err)
+			resources, _, _, err := accessKeyBuilder.List(ctx, nil, &pagination.Token{Token: token})
+			require.NoError(t, err)
+			require.Equal(t, serviceAccountResourceType.Id, secretTrait(t, resources[0]).IdentityId.ResourceType)
+		}
+		require.Equal(t, 2, serviceAccountCalls)
+	})
+}
+
+func TestAccessKeyDelete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should delete the access key", func(t *testing.T) {
+		accessKeyBuilder, mockClientService := newTestAccessKeyBuilder(true)
+		deleted := false
+		mockClientService.DeleteAccessKeyFunc = func(ctx context.Context, accessKeyId string) (*v2.RateLimitDescription, error) {
+			require.Equal(t, "key-1", accessKeyId)
+			deleted = true
+			return nil, nil
+		}
+
+		_, err := accessKeyBuilder.Delete(ctx, &v2.ResourceId{ResourceType: accessKeyResourceType.Id, Resource: "key-1"})
+		require.NoError(t, err)
+		require.True(t, deleted)
+	})
+
+	t.Run("should succeed when the access key was already deleted", func(t *testing.T) {
+		accessKeyBuilder, mockClientService := newTestAccessKeyBuilder(true)
+		mockClientService.DeleteAccessKeyFunc = func(ctx context.Context, accessKeyId string) (*v2.RateLimitDescription, error) {
+			return nil, status.Error(codes.NotFound, "access_key:not_found")
+		}
+
+		_, err := accessKeyBuilder.Delete(ctx, &v2.ResourceId{ResourceType: accessKeyResourceType.Id, Resource: "key-1"})
+		require.NoError(t, err)
+	})
+
+	t.Run("should refuse to delete the connector access key", func(t *testing.T) {
+		accessKeyBuilder, _ := newTestAccessKeyBuilder(true)
+
+		_, err := accessKeyBuilder.Delete(ctx, &v2.ResourceId{ResourceType: accessKeyResourceType.Id, Resource: "CONNECTOR-KEY"})
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestAccessKeyActions(t *testing.T) {
+	ctx := context.Background()
+
+	// newAccessKeyActionManager lists key-1, restricted to a CORS domain, and the connector key.
+	newAccessKeyActionManager := func() (*actionManager, *client.MockClientService) {
+		manager, mockClientService := newTestActionManager()
+		mockClientService.GetAccessKeysFunc = func(ctx context.Context, pageToken *string) ([]*client.AccessKeyResponse, *string, *v2.RateLimitDescription, error) {
+			return []*client.AccessKeyResponse{
+				{ID: "key-1", CorsHeaders: []string{"https://example.com"}},
+				{ID: "connector-key", Disabled: true},
+			}, nil, nil, nil
+		}
+		return manager, mockClientService
+	}
+
+	t.Run("should disable the access key and keep its CORS domains", func(t *testing.T) {
+		manager, mockClientService := newAccessKeyActionManager()
+		mockClientService.UpdateAccessKeyFunc = func(
+			ctx context.Context,
+			accessKeyId string,
+			accessKeyRequest client.AccessKeyUpdateRequest,
+		) (*client.AccessKeyResponse, *v2.RateLimitDescription, error) {
+			require.Equal(t, "key-1", accessKeyId)
+			require.True(t, accessKeyRequest.Disabled)
+			require.Equal(t, []string{"https://example.com"}, accessKeyRequest.CorsHeaders)
+			return &client.AccessKeyResponse{ID: accessKeyId, Disabled: true}, nil, nil
+		}
+
+		_, actionStatus, response, _, err := manager.InvokeAction(ctx, disableAccessKeyAction, newActionArgs(t, map[string]interface{}{
+			accessKeyIDArgument: "key-1",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, v2.BatonActionStatus_BATON_ACTION_STATUS_COMPLETE, actionStatus)
+		require.Equal(t, true, response.AsMap()["disabled"])
+	})
+
+	t.Run("should enable the access key", func(t *testing.T) {
+		manager, mockClientService := newAccessKeyActionManager()
+		mockClientService.UpdateAccessKeyFunc = func(
+			ctx context.Context,
+			accessKeyId string,
+			accessKeyRequest client.AccessKeyUpdateRequest,
+		) (*client.AccessKeyResponse, *v2.RateLimitDescription, error) {
+			require.False(t, accessKeyRequest.Disabled)
+			return &client.AccessKeyResponse{ID: accessKeyId}, nil, nil
+		}
+
+		_, _, response, _, err := manager.InvokeAction(ctx, enableAccessKeyAction, newActionArgs(t, map[string]interface{}{
+			accessKeyIDArgument: "connector-key",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, false, response.AsMap()["disabled"])
+	})
+
+	t.Run("should fail when the access key does not exist", func(t *testing.T) {
+		manager, _ := newAccessKeyActionManager()
+
+		_, _, _, _, err := manager.InvokeAction(ctx, disableAccessKeyAction, newActionArgs(t, map[string]interface{}{
+			accessKeyIDArgument: "missing-key",
+		}))
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("should refuse to disable the connector access key", func(t *testing.T) {
+		manager, _ := newTestActionManager()
+
+		_, _, _, _, err := manager.InvokeAction(ctx, disableAccessKeyAction, newActionArgs(t, map[string]interface{}{
+			accessKeyIDArgument: "connector-key",
+		}))
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestRetireAccessKeyAction(t *testing.T) {
+	ctx := context.Background()
+
+	// newRetireActionManager lists old-key, replaced by new-key an hour ago, with a 30 minute rotation overlap.
+	newRetireActionManager := func(t *testing.T) (*actionManager, *[]string) {
+		manager, mockClientService := newTestActionManager()
+		manager.accessKeyRotationOverlap = 30 * time.Minute
+
+		mockClientService.GetAccessKeysFunc = func(ctx context.Context, pageToken *string) ([]*client.AccessKeyResponse, *string, *v2.RateLimitDescription, error) {
+			return []*client.AccessKeyResponse{
+				{ID: "old-key", CreatedBy: "service-1", CreatedAt: time.Now().Add(-48 * time.Hour), CorsHeaders: []string{"https://example.com"}},
+				{ID: "new-key", CreatedBy: "service-1", CreatedAt: time.Now().Add(-time.Hour)},
+				{ID: "other-key", CreatedBy: "service-2", CreatedAt: time.Now().Add(-time.Hour)},
+				{ID: "connector-key", CreatedBy: "service-1", CreatedAt: time.Now().Add(-72 * time.Hour)},
+			}, nil, nil, nil
+		}
+
+		var retired []string
+		disabled := make(map[string]bool)
+		mockClientService.UpdateAccessKeyFunc = func(
+			ctx context.Context,
+			accessKeyId string,
+			accessKeyRequest client.AccessKeyUpdateRequest,
+		) (*client.AccessKeyResponse, *v2.RateLimitDescription, error) {
+			require.True(t, accessKeyRequest.Disabled)
+			require.Equal(t, []string{"https://example.com"}, accessKeyRequest.CorsHeaders)
+			disabled[accessKeyId] = true
+			return &client.AccessKeyResponse{ID: accessKeyId, Disabled: true}, nil, nil
+		}
+		mockClientService.DeleteAccessKeyFunc = func(ctx context.Context, accessKeyId string) (*v2.RateLimitDescription, error) {
+			require.True(t, disabled[accessKeyId], "access key must be disabled before it is deleted")
+			retired = append(retired, accessKeyId)
+			return nil, nil
+		}
+
+		return manager, &retired
+	}
+
+	t.Run("should retire the replaced access key after the overlap", func(t *testing.T) {
+		manager, retired := newRetireActionManager(t)
+
+		_, actionStatus, response, _, err := manager.InvokeAction(ctx, retireAccessKeyAction, newActionArgs(t, map[string]interface{}{
+			accessKeyIDArgument: "old-key",
+			replacedByArgument:  "new-key",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, v2.BatonActionStatus_BATON_ACTION_STATUS_COMPLETE, actionStatus)
+		require.Equal(t, true, response.AsMap()["success"])
+		require.Equal(t, []string{"old-key"}, *retired)
+	})
+
+	t.Run("should keep the access key during the overlap", func(t *testing.T) {
+		manager, retired := newRetireActionManager(t)
+		manager.accessKeyRotationOverlap = 24 * time.Hour
+
+		_, _, _, _, err := manager.InvokeAction(ctx, retireAccessKeyAction, newActionArgs(t, map[string]interface{}{
+			accessKeyIDArgument: "old-key",
+			replacedByArgument:  "new-key",
+		}))
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Empty(t, *retired)
+	})
+
+	t.Run("should refuse a replacement from another account", func(t *testing.T) {
+		manager, retired := newRetireActionManager(t)
+
+		_, _, _, _, err := manager.InvokeAction(ctx, retireAccessKeyAction, newActionArgs(t, map[string]interface{}{
+			accessKeyIDArgument: "old-key",
+			replacedByArgument:  "other-key",
+		}))
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Empty(t, *retired)
+	})
+
+	t.Run("should refuse a replacement older than the access key", func(t *testing.T) {
+		manager, retired := newRetireActionManager(t)
+
+		_, _, _, _, err := manager.InvokeAction(ctx, retireAccessKeyAction, newActionArgs(t, map[string]interface{}{
+			accessKeyIDArgument: "new-key",
+			replacedByArgument:  "old-key",
+		}))
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Empty(t, *retired)
+	})
+
+	t.Run("should fail when the replacement does not exist", func(t *testing.T) {
+		manager, retired := newRetireActionManager(t)
+
+		_, _, _, _, err := manager.InvokeAction(ctx, retireAccessKeyAction, newActionArgs(t, map[string]interface{}{
+			accessKeyIDArgument: "old-key",
+			replacedByArgument:  "missing-key",
+		}))
+		require.Equal(t, codes.NotFound, status.Code(err))
+		require.Empty(t, *retired)
+	})
+
+	t.Run("should refuse to retire the connector access key", func(t *testing.T) {
+		manager, retired := newRetireActionManager(t)
+
+		_, _, _, _, err := manager.InvokeAction(ctx, retireAccessKeyAction, newActionArgs(t, map[string]interface{}{
+			accessKeyIDArgument: "connector-key",
+			replacedByArgument:  "new-key",
+		}))
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Empty(t, *retired)
+	})
+}
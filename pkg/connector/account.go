@@ -0,0 +1,86 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	accountOwnerEntitlement = "owner"
+
+	// The API has no identifier for the account, there is a single one per access key.
+	accountResourceID = "account"
+)
+
+type accountBuilder struct {
+	service client.ClientService
+}
+
+func (o *accountBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return accountResourceType
+}
+
+// List returns the Sumo Logic account the connector is authenticated with.
+func (o *accountBuilder) List(_ context.Context, _ *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	accountResource, err := rs.NewResource("Sumo Logic Account", accountResourceType, accountResourceID)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to create account resource: %w", err)
+	}
+
+	return []*v2.Resource{accountResource}, "", nil, nil
+}
+
+// Entitlements returns the owner entitlement, held by a single user.
+func (o *accountBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	ownerOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(userResourceType),
+		ent.WithDisplayName("Account Owner"),
+		ent.WithDescription("Owns the Sumo Logic account, the API cannot change it so a transfer goes through Sumo Logic support"),
+	}
+
+	return []*v2.Entitlement{ent.NewAssignmentEntitlement(resource, accountOwnerEntitlement, ownerOptions...)}, "", nil, nil
+}
+
+// Grants returns the owner of the account.
+// Reading the owner may require more privileges than syncing, so the grant is skipped when it is denied.
+func (o *accountBuilder) Grants(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	owner, rateLimit, err := o.service.GetAccountOwner(ctx)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if status.Code(err) == codes.PermissionDenied {
+		ctxzap.Extract(ctx).Warn("baton-sumo-logic: the access key is not allowed to read the account owner, skipping the owner grant", zap.Error(err))
+		return nil, "", outputAnnotations, nil
+	}
+	if err != nil {
+		return nil, "", outputAnnotations, fmt.Errorf("failed to get account owner: %w", err)
+	}
+	if owner == "" {
+		return nil, "", outputAnnotations, nil
+	}
+
+	ownerID := &v2.ResourceId{
+		ResourceType: userResourceType.Id,
+		Resource:     owner,
+	}
+
+	return []*v2.Grant{grant.NewGrant(resource, accountOwnerEntitlement, ownerID)}, "", outputAnnotations, nil
+}
+
+func newAccountBuilder(cclient *client.Client) *accountBuilder {
+	return &accountBuilder{
+		service: client.NewClientService(cclient),
+	}
+}
@@ -0,0 +1,61 @@
+package connector
+
+import (
+	"context"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Helper function to create a test builder with mocks.
+func newTestAccountBuilder() (*accountBuilder, *client.MockClientService) {
+	mockClient := &client.Client{}
+	mockClientService := &client.MockClientService{}
+
+	builder := newAccountBuilder(mockClient)
+	// Replace the service with our mock.
+	builder.service = mockClientService
+
+	return builder, mockClientService
+}
+
+func TestAccountGrants(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should grant the owner entitlement to the account owner", func(t *testing.T) {
+		accountBuilder, mockClientService := newTestAccountBuilder()
+		mockClientService.GetAccountOwnerFunc = func(ctx context.Context) (string, *v2.RateLimitDescription, error) {
+			return "owner-1", nil, nil
+		}
+
+		resources, _, _, err := accountBuilder.List(ctx, nil, &pagination.Token{})
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+
+		grants, _, _, err := accountBuilder.Grants(ctx, resources[0], &pagination.Token{})
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		require.Equal(t, "account:account:owner", grants[0].Entitlement.Id)
+		require.Equal(t, userResourceType.Id, grants[0].Principal.Id.ResourceType)
+		require.Equal(t, "owner-1", grants[0].Principal.Id.Resource)
+	})
+
+	t.Run("should skip the owner grant when reading it is denied", func(t *testing.T) {
+		accountBuilder, mockClientService := newTestAccountBuilder()
+		mockClientService.GetAccountOwnerFunc = func(ctx context.Context) (string, *v2.RateLimitDescription, error) {
+			return "", nil, status.Error(codes.PermissionDenied, "request failed with status 403")
+		}
+
+		resources, _, _, err := accountBuilder.List(ctx, nil, &pagination.Token{})
+		require.NoError(t, err)
+
+		grants, _, _, err := accountBuilder.Grants(ctx, resources[0], &pagination.Token{})
+		require.NoError(t, err)
+		require.Empty(t, grants)
+	})
+}
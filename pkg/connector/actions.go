@@ -0,0 +1,215 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/conductorone/baton-sdk/pb/c1/config/v1"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type actionHandler func(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error)
+
+type customAction struct {
+	schema  *v2.BatonActionSchema
+	handler actionHandler
+}
+
+// actionManager implements the CustomActionManager interface.
+// Every action runs synchronously, so its status is returned by InvokeAction.
+type actionManager struct {
+	service     client.ClientService
+	apiAccessID string
+	// accessKeyRotationOverlap is how long a rotated access key keeps working before it can be retired.
+	accessKeyRotationOverlap time.Duration
+	// userContentSuccessor is the default recipient of the content of deleted users.
+	userContentSuccessor string
+	actions              map[string]*customAction
+}
+
+func (m *actionManager) register(schema *v2.BatonActionSchema, handler actionHandler) {
+	m.actions[schema.Name] = &customAction{
+		schema:  schema,
+		handler: handler,
+	}
+}
+
+func (m *actionManager) ListActionSchemas(_ context.Context) ([]*v2.BatonActionSchema, annotations.Annotations, error) {
+	rv := make([]*v2.BatonActionSchema, 0, len(m.actions))
+	for _, action := range m.actions {
+		rv = append(rv, action.schema)
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		return rv[i].Name < rv[j].Name
+	})
+
+	return rv, nil, nil
+}
+
+func (m *actionManager) GetActionSchema(_ context.Context, name string) (*v2.BatonActionSchema, annotations.Annotations, error) {
+	action, ok := m.actions[name]
+	if !ok {
+		return nil, nil, status.Errorf(codes.NotFound, "baton-sumo-logic: unknown action %s", name)
+	}
+
+	return action.schema, nil, nil
+}
+
+func (m *actionManager) InvokeAction(
+	ctx context.Context,
+	name string,
+	args *structpb.Struct,
+) (string, v2.BatonActionStatus, *structpb.Struct, annotations.Annotations, error) {
+	action, ok := m.actions[name]
+	if !ok {
+		return "", v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED, nil, nil, status.Errorf(codes.NotFound, "baton-sumo-logic: unknown action %s", name)
+	}
+
+	actionID := uuid.NewString()
+	l := ctxzap.Extract(ctx).With(zap.String("action", name), zap.String("action_id", actionID))
+
+	response, outputAnnotations, err := action.handler(ctx, args)
+	if err != nil {
+		l.Error("baton-sumo-logic: action failed", zap.Error(err))
+		return actionID, v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED, nil, outputAnnotations, err
+	}
+
+	l.Info("baton-sumo-logic: action completed")
+	return actionID, v2.BatonActionStatus_BATON_ACTION_STATUS_COMPLETE, response, outputAnnotations, nil
+}
+
+// GetActionStatus is not supported, since actions complete before InvokeAction returns.
+func (m *actionManager) GetActionStatus(_ context.Context, id string) (v2.BatonActionStatus, string, *structpb.Struct, annotations.Annotations, error) {
+	return v2.BatonActionStatus_BATON_ACTION_STATUS_UNKNOWN, "", nil, nil, status.Errorf(
+		codes.NotFound,
+		"baton-sumo-logic: action %s is unknown, actions complete synchronously",
+		id,
+	)
+}
+
+func newActionManager(
+	service client.ClientService,
+	apiAccessID string,
+	accessKeyRotationOverlap time.Duration,
+	userContentSuccessor string,
+) *actionManager {
+	m := &actionManager{
+		service:                  service,
+		apiAccessID:              apiAccessID,
+		accessKeyRotationOverlap: accessKeyRotationOverlap,
+		userContentSuccessor:     userContentSuccessor,
+		actions:                  make(map[string]*customAction),
+	}
+
+	m.registerAccessKeyActions()
+	m.registerUserActions()
+
+	return m
+}
+
+// stringArgument returns a required string argument of an action.
+func stringArgument(args *structpb.Struct, name string) (string, error) {
+	value, ok := args.GetFields()[name]
+	if !ok {
+		return "", status.Errorf(codes.InvalidArgument, "baton-sumo-logic: missing argument %s", name)
+	}
+
+	s, ok := value.GetKind().(*structpb.Value_StringValue)
+	if !ok || strings.TrimSpace(s.StringValue) == "" {
+		return "", status.Errorf(codes.InvalidArgument, "baton-sumo-logic: argument %s must be a non-empty string", name)
+	}
+
+	return strings.TrimSpace(s.StringValue), nil
+}
+
+// optionalStringArgument returns an optional string argument of an action, and whether it was given.
+func optionalStringArgument(args *structpb.Struct, name string) (string, bool, error) {
+	value, ok := args.GetFields()[name]
+	if !ok {
+		return "", false, nil
+	}
+	if _, ok := value.GetKind().(*structpb.Value_NullValue); ok {
+		return "", false, nil
+	}
+
+	s, ok := value.GetKind().(*structpb.Value_StringValue)
+	if !ok || strings.TrimSpace(s.StringValue) == "" {
+		return "", false, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: argument %s must be a non-empty string", name)
+	}
+
+	return strings.TrimSpace(s.StringValue), true, nil
+}
+
+// stringListArgument returns an optional list argument of an action, given as a list or a comma separated string,
+// and whether it was given.
+func stringListArgument(args *structpb.Struct, name string) ([]string, bool, error) {
+	value, ok := args.GetFields()[name]
+	if !ok {
+		return nil, false, nil
+	}
+	if _, ok := value.GetKind().(*structpb.Value_NullValue); ok {
+		return nil, false, nil
+	}
+
+	rv, err := profileStringList(map[string]interface{}{name: value.AsInterface()}, name)
+	if err != nil {
+		return nil, false, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: %s", err)
+	}
+
+	return rv, true, nil
+}
+
+func stringActionField(name, displayName, description string, required bool) *v1.Field {
+	return &v1.Field{
+		Name:        name,
+		DisplayName: displayName,
+		Description: description,
+		IsRequired:  required,
+		Field:       &v1.Field_StringField{StringField: &v1.StringField{}},
+	}
+}
+
+func stringListActionField(name, displayName, description string, required bool) *v1.Field {
+	return &v1.Field{
+		Name:        name,
+		DisplayName: displayName,
+		Description: description,
+		IsRequired:  required,
+		Field:       &v1.Field_StringSliceField{StringSliceField: &v1.StringSliceField{}},
+	}
+}
+
+func boolActionField(name, displayName, description string) *v1.Field {
+	return &v1.Field{
+		Name:        name,
+		DisplayName: displayName,
+		Description: description,
+		Field:       &v1.Field_BoolField{BoolField: &v1.BoolField{}},
+	}
+}
+
+// actionResponse builds the response of an action, reporting success alongside the given values.
+func actionResponse(values map[string]interface{}) (*structpb.Struct, error) {
+	response := map[string]interface{}{"success": true}
+	for k, v := range values {
+		response[k] = v
+	}
+
+	rv, err := structpb.NewStruct(response)
+	if err != nil {
+		return nil, fmt.Errorf("baton-sumo-logic: failed to build the action response: %w", err)
+	}
+
+	return rv, nil
+}
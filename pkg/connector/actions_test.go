@@ -0,0 +1,62 @@
+package connector
+
+import (
+	"context"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Helper function to create a test action manager with mocks.
+func newTestActionManager() (*actionManager, *client.MockClientService) {
+	mockClientService := &client.MockClientService{}
+	return newActionManager(mockClientService, "connector-key", 0, ""), mockClientService
+}
+
+func newActionArgs(t *testing.T, args map[string]interface{}) *structpb.Struct {
+	rv, err := structpb.NewStruct(args)
+	require.NoError(t, err)
+	return rv
+}
+
+func TestActionManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should list the registered action schemas", func(t *testing.T) {
+		manager, _ := newTestActionManager()
+
+		schemas, _, err := manager.ListActionSchemas(ctx)
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(schemas))
+		for _, schema := range schemas {
+			names = append(names, schema.Name)
+		}
+		require.Contains(t, names, disableAccessKeyAction)
+		require.Contains(t, names, enableAccessKeyAction)
+	})
+
+	t.Run("should reject unknown actions", func(t *testing.T) {
+		manager, _ := newTestActionManager()
+
+		_, _, err := manager.GetActionSchema(ctx, "unknown")
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		_, actionStatus, _, _, err := manager.InvokeAction(ctx, "unknown", newActionArgs(t, nil))
+		require.Equal(t, codes.NotFound, status.Code(err))
+		require.Equal(t, v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED, actionStatus)
+	})
+
+	t.Run("should reject missing arguments", func(t *testing.T) {
+		manager, _ := newTestActionManager()
+
+		_, actionStatus, _, _, err := manager.InvokeAction(ctx, disableAccessKeyAction, newActionArgs(t, nil))
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Equal(t, v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED, actionStatus)
+	})
+}
@@ -0,0 +1,279 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+const capabilityGrantedEntitlement = "granted"
+
+// knownCapabilities is the catalog of role capabilities documented by Sumo Logic.
+// The API has no endpoint to list them, so capabilities found on roles are synced as well.
+// API Doc: https://api.sumologic.com/docs/#operation/createRole
+var knownCapabilities = []string{
+	"viewCollectors",
+	"manageCollectors",
+	"manageBudgets",
+	"manageDataVolumeFeed",
+	"viewFieldExtraction",
+	"manageFieldExtractionRules",
+	"manageS3DataForwarding",
+	"manageContent",
+	"manageApps",
+	"dataVolumeIndex",
+	"manageConnections",
+	"viewScheduledViews",
+	"manageScheduledViews",
+	"viewPartitions",
+	"managePartitions",
+	"viewFields",
+	"manageFields",
+	"viewAccountOverview",
+	"manageTokens",
+	"downloadSearchResults",
+	"manageMonitors",
+	"metricsTransformation",
+	"metricsExtraction",
+	"metricsRules",
+	"managePasswordPolicy",
+	"ipAllowlisting",
+	"createAccessKeys",
+	"manageAccessKeys",
+	"manageSupportAccountAccess",
+	"manageAuditDataFeed",
+	"manageSaml",
+	"shareDashboardWhitelist",
+	"shareDashboardWorld",
+	"manageOrgSettings",
+	"changeDataAccessLevel",
+	"viewUsersAndRoles",
+	"manageUsersAndRoles",
+}
+
+type capabilityBuilder struct {
+	service client.ClientService
+
+	// emitted holds the capabilities listed so far in the current sync, so one found on several role pages is only listed once.
+	mu      sync.Mutex
+	emitted map[string]struct{}
+}
+
+func (o *capabilityBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return capabilityResourceType
+}
+
+// List returns the known capability catalog on the first page, then pages through the roles
+// and returns the capabilities they hold that were not listed yet.
+func (o *capabilityBuilder) List(ctx context.Context, _ *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	roles, nextPageToken, rateLimit, err := o.service.GetRoles(ctx, parsePageToken(pToken))
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, "", outputAnnotations, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var names []string
+	// A new sync starts by listing the first page again, so the catalog is listed and the capabilities found are reset.
+	if pToken == nil || pToken.Token == "" {
+		o.emitted = make(map[string]struct{}, len(knownCapabilities))
+		for _, capability := range knownCapabilities {
+			o.emitted[capability] = struct{}{}
+			names = append(names, capability)
+		}
+	}
+
+	for _, role := range roles {
+		if role.Capabilities == nil {
+			continue
+		}
+		for _, capability := range *role.Capabilities {
+			if _, ok := o.emitted[capability]; ok {
+				continue
+			}
+			o.emitted[capability] = struct{}{}
+			names = append(names, capability)
+		}
+	}
+	sort.Strings(names)
+
+	resources := make([]*v2.Resource, 0, len(names))
+	for _, name := range names {
+		capabilityResource, err := createCapabilityResource(name)
+		if err != nil {
+			return nil, "", outputAnnotations, fmt.Errorf("failed to create capability resource: %w", err)
+		}
+		resources = append(resources, capabilityResource)
+	}
+
+	return resources, createPageToken(nextPageToken), outputAnnotations, nil
+}
+
+func (o *capabilityBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+
+	permissionOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(roleResourceType),
+		ent.WithDisplayName(fmt.Sprintf("%s Capability", resource.DisplayName)),
+		ent.WithDescription(fmt.Sprintf("Has the %s capability in Sumo Logic", resource.DisplayName)),
+	}
+
+	rv = append(rv, ent.NewPermissionEntitlement(resource, capabilityGrantedEntitlement, permissionOptions...))
+
+	return rv, "", nil, nil
+}
+
+// Grants always returns an empty slice for capabilities.
+// Capability grants are emitted by the role syncer, which already fetches each role's capabilities.
+func (o *capabilityBuilder) Grants(_ context.Context, _ *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+// Grant adds the capability to a role by rewriting the role with its current settings plus the new capability.
+func (o *capabilityBuilder) Grant(
+	ctx context.Context,
+	principal *v2.Resource,
+	entitlement *v2.Entitlement,
+) (annotations.Annotations, error) {
+	logger := ctxzap.Extract(ctx)
+
+	if principal.Id.ResourceType != roleResourceType.Id {
+		logger.Error(
+			"baton-sumo-logic: only roles can be granted a capability",
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+		return nil, fmt.Errorf("baton-sumo-logic: only roles can be granted a capability")
+	}
+
+	outputAnnotations := annotations.New()
+	capability := entitlement.Resource.Id.Resource
+
+	// The update rewrites the whole role, so it is read without the cache to keep changes made since the last sync.
+	role, rateLimit, err := o.service.GetRole(client.WithoutCache(ctx), principal.Id.Resource)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get role: %w", err)
+	}
+
+	if role.SystemDefined != nil && *role.SystemDefined {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: role %s is defined by the system and cannot be modified", role.Name)
+	}
+
+	roleRequest := roleResponseToRequest(role)
+	if slices.Contains(roleRequest.Capabilities, capability) {
+		outputAnnotations.Append(&v2.GrantAlreadyExists{})
+		return outputAnnotations, nil
+	}
+	roleRequest.Capabilities = append(roleRequest.Capabilities, capability)
+
+	_, rateLimit, err = o.service.UpdateRole(ctx, role.ID, roleRequest)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to grant capability to role: %w", err)
+	}
+
+	return outputAnnotations, nil
+}
+
+// Revoke removes the capability from a role by rewriting the role without it.
+func (o *capabilityBuilder) Revoke(
+	ctx context.Context,
+	grant *v2.Grant,
+) (
+	annotations.Annotations,
+	error,
+) {
+	logger := ctxzap.Extract(ctx)
+
+	if grant.Principal.Id.ResourceType != roleResourceType.Id {
+		logger.Error(
+			"baton-sumo-logic: only roles can be revoked a capability",
+			zap.String("principal_type", grant.Principal.Id.ResourceType),
+			zap.String("principal_id", grant.Principal.Id.Resource),
+		)
+		return nil, fmt.Errorf("baton-sumo-logic: only roles can be revoked a capability")
+	}
+
+	outputAnnotations := annotations.New()
+	capability := grant.Entitlement.Resource.Id.Resource
+
+	// Read without the cache, as in Grant.
+	role, rateLimit, err := o.service.GetRole(client.WithoutCache(ctx), grant.Principal.Id.Resource)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get role: %w", err)
+	}
+
+	// System defined roles cannot be modified, Sumo Logic would reject the update.
+	if role.SystemDefined != nil && *role.SystemDefined {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: role %s is defined by the system and cannot be modified", role.Name)
+	}
+
+	roleRequest := roleResponseToRequest(role)
+	if !slices.Contains(roleRequest.Capabilities, capability) {
+		outputAnnotations.Append(&v2.GrantAlreadyRevoked{})
+		return outputAnnotations, nil
+	}
+	roleRequest.Capabilities = slices.DeleteFunc(roleRequest.Capabilities, func(c string) bool {
+		return c == capability
+	})
+
+	_, rateLimit, err = o.service.UpdateRole(ctx, role.ID, roleRequest)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to revoke capability from role: %w", err)
+	}
+
+	return outputAnnotations, nil
+}
+
+func newCapabilityBuilder(cclient *client.Client) *capabilityBuilder {
+	return &capabilityBuilder{
+		service: client.NewClientService(cclient),
+	}
+}
+
+func createCapabilityResource(capability string) (*v2.Resource, error) {
+	displayName := capabilityDisplayName(capability)
+
+	return rs.NewResource(
+		displayName,
+		capabilityResourceType,
+		capability,
+		rs.WithDescription(fmt.Sprintf("Sumo Logic capability %s", capability)),
+	)
+}
+
+// capabilityDisplayName turns a capability identifier such as "manageUsersAndRoles" into "Manage Users And Roles".
+func capabilityDisplayName(capability string) string {
+	var b strings.Builder
+	for i, r := range capability {
+		if i == 0 {
+			b.WriteRune(unicode.ToUpper(r))
+			continue
+		}
+		if unicode.IsUpper(r) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
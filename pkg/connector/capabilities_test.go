@@ -0,0 +1,201 @@
+package connector
+
+import (
+	"context"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/stretchr/testify/require"
+)
+
+// Helper function to create a test builder with mocks.
+func newTestCapabilityBuilder() (*capabilityBuilder, *client.MockClientService) {
+	mockClient := &client.Client{}
+	mockClientService := &client.MockClientService{}
+
+	builder := newCapabilityBuilder(mockClient)
+	// Replace the service with our mock.
+	builder.service = mockClientService
+
+	return builder, mockClientService
+}
+
+func TestCapabilitiesList(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should list the catalog and capabilities found on every role page", func(t *testing.T) {
+		capabilityBuilder, mockClientService := newTestCapabilityBuilder()
+
+		nextToken := "page-2"
+		mockClientService.GetRolesFunc = func(
+			ctx context.Context,
+			pageToken *string,
+		) (
+			[]*client.RoleResponse,
+			*string,
+			*v2.RateLimitDescription,
+			error,
+		) {
+			if pageToken == nil || *pageToken == "" {
+				capabilities := []string{"manageUsersAndRoles", "customCapability"}
+				return []*client.RoleResponse{{ID: "1", Capabilities: &capabilities}}, &nextToken, nil, nil
+			}
+			require.Equal(t, nextToken, *pageToken)
+			capabilities := []string{"customCapability"}
+			return []*client.RoleResponse{{ID: "2", Capabilities: &capabilities}}, nil, nil, nil
+		}
+
+		// Two syncs in a row list every capability once each.
+		for range 2 {
+			resources, token, _, err := capabilityBuilder.List(ctx, nil, &pagination.Token{})
+			require.NoError(t, err)
+			require.Equal(t, nextToken, token)
+			require.Len(t, resources, len(knownCapabilities)+1)
+
+			nextResources, token, _, err := capabilityBuilder.List(ctx, nil, &pagination.Token{Token: token})
+			require.NoError(t, err)
+			require.Empty(t, token)
+			require.Empty(t, nextResources)
+
+			seen := make(map[string]int)
+			for _, resource := range resources {
+				seen[resource.Id.Resource]++
+			}
+			require.Equal(t, 1, seen["customCapability"])
+			require.Equal(t, 1, seen["manageUsersAndRoles"])
+		}
+	})
+}
+
+func TestCapabilityDisplayName(t *testing.T) {
+	require.Equal(t, "Manage Users And Roles", capabilityDisplayName("manageUsersAndRoles"))
+	require.Equal(t, "View Collectors", capabilityDisplayName("viewCollectors"))
+}
+
+func TestRoleCapabilityGrants(t *testing.T) {
+	ctx := context.Background()
+
+	roleBuilder, mockClientService := newTestRoleBuilder()
+	mockClientService.GetRoleFunc = func(ctx context.Context, roleId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+		users := []string{"user-1"}
+		capabilities := []string{"manageUsersAndRoles"}
+		return &client.RoleResponse{ID: roleId, Users: &users, Capabilities: &capabilities}, nil, nil
+	}
+
+	roleResource, err := createRoleResource(&client.RoleResponse{ID: "role-1", Name: "Administrator"})
+	require.NoError(t, err)
+
+	grants, _, _, err := roleBuilder.Grants(ctx, roleResource, &pagination.Token{})
+	require.NoError(t, err)
+	require.Len(t, grants, 2)
+
+	capabilityGrant := grants[1]
+	require.Equal(t, capabilityResourceType.Id, capabilityGrant.Entitlement.Resource.Id.ResourceType)
+	require.Equal(t, "manageUsersAndRoles", capabilityGrant.Entitlement.Resource.Id.Resource)
+	require.Equal(t, roleResource.Id.Resource, capabilityGrant.Principal.Id.Resource)
+
+	expandable := &v2.GrantExpandable{}
+	grantAnnotations := annotations.Annotations(capabilityGrant.Annotations)
+	ok, err := grantAnnotations.Pick(expandable)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []string{"role:role-1:assigned"}, expandable.EntitlementIds)
+}
+
+func TestCapabilityGrantAndRevoke(t *testing.T) {
+	ctx := context.Background()
+
+	roleResource := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: roleResourceType.Id,
+			Resource:     "test-role",
+		},
+	}
+
+	capabilityEntitlement := &v2.Entitlement{
+		Resource: &v2.Resource{
+			Id: &v2.ResourceId{
+				ResourceType: capabilityResourceType.Id,
+				Resource:     "manageUsersAndRoles",
+			},
+		},
+	}
+
+	newRole := func(systemDefined bool, capabilities ...string) *client.RoleResponse {
+		description := "Test Role"
+		filterPredicate := "_sourceCategory=test"
+		users := []string{"test-user"}
+		autofill := false
+		return &client.RoleResponse{
+			ID:                   "test-role",
+			Name:                 "baton-role",
+			Description:          &description,
+			FilterPredicate:      &filterPredicate,
+			Users:                &users,
+			Capabilities:         &capabilities,
+			AutofillDependencies: &autofill,
+			SystemDefined:        &systemDefined,
+		}
+	}
+
+	t.Run("Grant preserves the existing role settings", func(t *testing.T) {
+		capabilityBuilder, mockService := newTestCapabilityBuilder()
+		mockService.GetRoleFunc = func(ctx context.Context, roleId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			return newRole(false, "viewCollectors"), nil, nil
+		}
+		mockService.UpdateRoleFunc = func(ctx context.Context, roleId string, roleRequest client.RoleRequest) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			require.Equal(t, "test-role", roleId)
+			require.Equal(t, "baton-role", roleRequest.Name)
+			require.Equal(t, "Test Role", roleRequest.Description)
+			require.Equal(t, "_sourceCategory=test", roleRequest.FilterPredicate)
+			require.Equal(t, []string{"test-user"}, roleRequest.Users)
+			require.Equal(t, []string{"viewCollectors", "manageUsersAndRoles"}, roleRequest.Capabilities)
+			require.NotNil(t, roleRequest.AutofillDependencies)
+			require.False(t, *roleRequest.AutofillDependencies)
+			return nil, nil, nil
+		}
+
+		_, err := capabilityBuilder.Grant(ctx, roleResource, capabilityEntitlement)
+		require.NoError(t, err)
+	})
+
+	t.Run("Grant rejects non-role principals", func(t *testing.T) {
+		capabilityBuilder, _ := newTestCapabilityBuilder()
+		principal := &v2.Resource{
+			Id: &v2.ResourceId{
+				ResourceType: userResourceType.Id,
+				Resource:     "test-user",
+			},
+		}
+
+		_, err := capabilityBuilder.Grant(ctx, principal, capabilityEntitlement)
+		require.ErrorContains(t, err, "only roles can be granted a capability")
+	})
+
+	t.Run("Revoke removes the capability", func(t *testing.T) {
+		capabilityBuilder, mockService := newTestCapabilityBuilder()
+		mockService.GetRoleFunc = func(ctx context.Context, roleId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			return newRole(false, "viewCollectors", "manageUsersAndRoles"), nil, nil
+		}
+		mockService.UpdateRoleFunc = func(ctx context.Context, roleId string, roleRequest client.RoleRequest) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			require.Equal(t, []string{"viewCollectors"}, roleRequest.Capabilities)
+			return nil, nil, nil
+		}
+
+		_, err := capabilityBuilder.Revoke(ctx, &v2.Grant{Principal: roleResource, Entitlement: capabilityEntitlement})
+		require.NoError(t, err)
+	})
+
+	t.Run("Revoke refuses system defined roles", func(t *testing.T) {
+		capabilityBuilder, mockService := newTestCapabilityBuilder()
+		mockService.GetRoleFunc = func(ctx context.Context, roleId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			return newRole(true, "manageUsersAndRoles"), nil, nil
+		}
+
+		_, err := capabilityBuilder.Revoke(ctx, &v2.Grant{Principal: roleResource, Entitlement: capabilityEntitlement})
+		require.ErrorContains(t, err, "defined by the system")
+	})
+}
@@ -3,6 +3,7 @@ package connector
 import (
 	"context"
 	"io"
+	"time"
 
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
@@ -12,15 +13,36 @@ import (
 
 type Connector struct {
 	client                 *client.Client
+	service                client.ClientService
+	apiAccessID            string
 	includeServiceAccounts bool
+	roleGrantsFromUsers    bool
+	includeAccessKeys      bool
+	provisioningEnabled    bool
+	// disableUsersOnDeprovision makes deprovisioning disable users instead of deleting them.
+	disableUsersOnDeprovision bool
+	// accessKeyRotationOverlap is how long a rotated service account access key keeps working.
+	accessKeyRotationOverlap time.Duration
+	// userContentSuccessor is the ID or email of the user who receives the content of deleted users.
+	userContentSuccessor string
 }
 
 // ResourceSyncers returns a ResourceSyncer for each resource type that should be synced from the upstream service.
 func (d *Connector) ResourceSyncers(ctx context.Context) []connectorbuilder.ResourceSyncer {
-	return []connectorbuilder.ResourceSyncer{
-		newUserBuilder(d.client, d.includeServiceAccounts),
-		newRoleBuilder(d.client),
+	syncers := []connectorbuilder.ResourceSyncer{
+		newUserBuilder(d.client, d.apiAccessID, d.disableUsersOnDeprovision, d.userContentSuccessor),
+		newRoleBuilder(d.client, d.roleGrantsFromUsers, d.includeServiceAccounts),
+		newCapabilityBuilder(d.client),
+		newAccountBuilder(d.client),
 	}
+	if d.includeServiceAccounts {
+		syncers = append(syncers, newServiceAccountBuilder(d.client, d.apiAccessID, d.accessKeyRotationOverlap))
+	}
+	if d.includeAccessKeys {
+		syncers = append(syncers, newAccessKeyBuilder(d.client, d.apiAccessID, d.includeServiceAccounts))
+	}
+
+	return syncers
 }
 
 // Asset takes an input AssetRef and attempts to fetch it using the connector's authenticated http client
@@ -66,14 +88,14 @@ func (d *Connector) Metadata(ctx context.Context) (*v2.ConnectorMetadata, error)
 					Placeholder: "email@example.com",
 					Order:       3,
 				},
-				"default_role_id": {
-					DisplayName: "Default Role ID",
+				"roles": {
+					DisplayName: "Roles",
 					Required:    true,
-					Description: "The default role ID to be associated with the user.",
-					Field: &v2.ConnectorAccountCreationSchema_Field_StringField{
-						StringField: &v2.ConnectorAccountCreationSchema_StringField{},
+					Description: "IDs or names of the roles to be associated with the user.",
+					Field: &v2.ConnectorAccountCreationSchema_Field_StringListField{
+						StringListField: &v2.ConnectorAccountCreationSchema_StringListField{},
 					},
-					Placeholder: "RoleID",
+					Placeholder: "Administrator",
 					Order:       4,
 				},
 			},
@@ -81,18 +103,74 @@ func (d *Connector) Metadata(ctx context.Context) (*v2.ConnectorMetadata, error)
 	}, nil
 }
 
+// RegisterActionManager implements the RegisterActionManager interface.
+func (d *Connector) RegisterActionManager(_ context.Context) (connectorbuilder.CustomActionManager, error) {
+	return newActionManager(d.service, d.apiAccessID, d.accessKeyRotationOverlap, d.userContentSuccessor), nil
+}
+
 // Validate is called to ensure that the connector is properly configured. It should exercise any API credentials
 // to be sure that they are valid.
 func (d *Connector) Validate(ctx context.Context) (annotations.Annotations, error) {
-	return nil, nil
+	outputAnnotations := annotations.New()
+
+	_, _, rateLimit, err := d.service.GetUsers(ctx, nil)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return outputAnnotations, validationError("list users", err)
+	}
+
+	_, _, rateLimit, err = d.service.GetRoles(ctx, nil)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return outputAnnotations, validationError("list roles", err)
+	}
+
+	if err := d.validateCapabilities(ctx); err != nil {
+		return outputAnnotations, err
+	}
+
+	return outputAnnotations, nil
+}
+
+// Config holds the options the connector is created with.
+type Config struct {
+	APIBaseURL      string
+	APIAccessID     string
+	APIAccessKey    string
+	RolesAPIVersion string
+	// IncludeServiceAccounts syncs service accounts as their own resource type.
+	IncludeServiceAccounts bool
+	// RoleGrantsFromUsers derives role grants from the roles listed on accounts instead of fetching each role.
+	RoleGrantsFromUsers bool
+	// IncludeAccessKeys syncs the access keys of users and service accounts.
+	IncludeAccessKeys bool
+	// ProvisioningEnabled is set when the connector runs with provisioning, which needs more capabilities.
+	ProvisioningEnabled bool
+	// AccessKeyRotationOverlap is how long a rotated service account access key keeps working.
+	AccessKeyRotationOverlap time.Duration
+	// DisableUsersOnDeprovision makes deprovisioning disable users instead of deleting them.
+	DisableUsersOnDeprovision bool
+	// UserContentSuccessor is the ID or email of the user who receives the content of deleted users.
+	UserContentSuccessor string
 }
 
 // New returns a new instance of the connector.
-func New(ctx context.Context, apiBaseURL, apiAccessID, apiAccessKey string, includeServiceAccounts bool) (*Connector, error) {
-	client, err := client.NewClient(ctx, apiBaseURL, apiAccessID, apiAccessKey)
+func New(ctx context.Context, config Config) (*Connector, error) {
+	cclient, err := client.NewClient(ctx, config.APIBaseURL, config.APIAccessID, config.APIAccessKey, config.RolesAPIVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Connector{client: client, includeServiceAccounts: includeServiceAccounts}, nil
+	return &Connector{
+		client:                    cclient,
+		service:                   client.NewClientService(cclient),
+		apiAccessID:               config.APIAccessID,
+		includeServiceAccounts:    config.IncludeServiceAccounts,
+		roleGrantsFromUsers:       config.RoleGrantsFromUsers,
+		includeAccessKeys:         config.IncludeAccessKeys,
+		provisioningEnabled:       config.ProvisioningEnabled,
+		accessKeyRotationOverlap:  config.AccessKeyRotationOverlap,
+		disableUsersOnDeprovision: config.DisableUsersOnDeprovision,
+		userContentSuccessor:      config.UserContentSuccessor,
+	}, nil
 }
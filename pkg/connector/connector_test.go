@@ -0,0 +1,108 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Helper function to create a test connector with mocks.
+func newTestConnector(provisioningEnabled bool) (*Connector, *client.MockClientService) {
+	mockClientService := &client.MockClientService{
+		GetUsersFunc: func(ctx context.Context, pageToken *string) ([]*client.UserResponse, *string, *v2.RateLimitDescription, error) {
+			return nil, nil, nil, nil
+		},
+		GetRolesFunc: func(ctx context.Context, pageToken *string) ([]*client.RoleResponse, *string, *v2.RateLimitDescription, error) {
+			return nil, nil, nil, nil
+		},
+		GetPersonalAccessKeysFunc: func(ctx context.Context) ([]*client.AccessKeyResponse, *v2.RateLimitDescription, error) {
+			return []*client.AccessKeyResponse{{ID: "access-id", CreatedBy: "owner"}}, nil, nil
+		},
+		GetUserByIDFunc: func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return &client.UserResponse{BaseAccount: client.BaseAccount{ID: userId, RoleIDs: []string{"role"}}}, nil, nil
+		},
+	}
+
+	connector := &Connector{
+		client:              &client.Client{},
+		service:             mockClientService,
+		apiAccessID:         "access-id",
+		provisioningEnabled: provisioningEnabled,
+	}
+
+	return connector, mockClientService
+}
+
+func withRoleCapabilities(mockClientService *client.MockClientService, capabilities ...string) {
+	mockClientService.GetRoleFunc = func(ctx context.Context, roleId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+		return &client.RoleResponse{ID: roleId, Capabilities: &capabilities}, nil, nil
+	}
+}
+
+func TestValidate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should succeed with the required capabilities", func(t *testing.T) {
+		connector, mockClientService := newTestConnector(true)
+		withRoleCapabilities(mockClientService, capabilityManageUsersAndRoles)
+
+		_, err := connector.Validate(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("should report invalid credentials", func(t *testing.T) {
+		connector, mockClientService := newTestConnector(false)
+		mockClientService.GetUsersFunc = func(ctx context.Context, pageToken *string) ([]*client.UserResponse, *string, *v2.RateLimitDescription, error) {
+			return nil, nil, nil, fmt.Errorf("error executing request: %w", status.Error(codes.Unauthenticated, "401 Unauthorized"))
+		}
+
+		_, err := connector.Validate(ctx)
+		require.ErrorContains(t, err, "api-access-id or api-access-key is invalid")
+	})
+
+	t.Run("should report the deployment redirect", func(t *testing.T) {
+		connector, mockClientService := newTestConnector(false)
+		mockClientService.GetUsersFunc = func(ctx context.Context, pageToken *string) ([]*client.UserResponse, *string, *v2.RateLimitDescription, error) {
+			return nil, nil, nil, fmt.Errorf("error executing request: %w", &client.DeploymentRedirectError{
+				StatusCode: 301,
+				Location:   "https://api.eu.sumologic.com/api/v1/users",
+			})
+		}
+
+		_, err := connector.Validate(ctx)
+		require.ErrorContains(t, err, "https://api.eu.sumologic.com/api/v1/users")
+	})
+
+	t.Run("should report missing capabilities by name", func(t *testing.T) {
+		connector, mockClientService := newTestConnector(true)
+		withRoleCapabilities(mockClientService, capabilityViewUsersAndRoles)
+
+		_, err := connector.Validate(ctx)
+		require.ErrorContains(t, err, capabilityManageUsersAndRoles)
+	})
+
+	t.Run("should require managing access keys when they are synced", func(t *testing.T) {
+		connector, mockClientService := newTestConnector(false)
+		connector.includeAccessKeys = true
+		withRoleCapabilities(mockClientService, capabilityViewUsersAndRoles)
+
+		_, err := connector.Validate(ctx)
+		require.ErrorContains(t, err, capabilityManageAccessKeys)
+	})
+
+	t.Run("should skip the capability check when the key owner cannot be resolved", func(t *testing.T) {
+		connector, mockClientService := newTestConnector(true)
+		mockClientService.GetPersonalAccessKeysFunc = func(ctx context.Context) ([]*client.AccessKeyResponse, *v2.RateLimitDescription, error) {
+			return nil, nil, nil
+		}
+
+		_, err := connector.Validate(ctx)
+		require.NoError(t, err)
+	})
+}
@@ -1,6 +1,9 @@
 package connector
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 )
 
@@ -17,3 +20,29 @@ func createPageToken(pageToken *string) string {
 	}
 	return *pageToken
 }
+
+// profileStringList reads a profile value given either as a list or as a comma separated string.
+func profileStringList(pMap map[string]interface{}, key string) ([]string, error) {
+	var rv []string
+	switch values := pMap[key].(type) {
+	case nil:
+	case string:
+		for _, value := range strings.Split(values, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				rv = append(rv, value)
+			}
+		}
+	case []interface{}:
+		for _, value := range values {
+			v, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s value %v: expected a string", key, value)
+			}
+			rv = append(rv, v)
+		}
+	default:
+		return nil, fmt.Errorf("invalid %s: expected a list or a comma separated string", key)
+	}
+
+	return rv, nil
+}
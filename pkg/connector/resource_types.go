@@ -12,9 +12,36 @@ var (
 		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_USER},
 	}
 
+	// The service account resource type is for the non-human accounts used for automation.
+	serviceAccountResourceType = &v2.ResourceType{
+		Id:          "service_account",
+		DisplayName: "Service Account",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_USER},
+	}
+
 	roleResourceType = &v2.ResourceType{
 		Id:          "role",
 		DisplayName: "Role",
 		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_ROLE},
 	}
+
+	// The capability resource type represents a single Sumo Logic capability (e.g. manageUsersAndRoles)
+	// that can be held by roles.
+	capabilityResourceType = &v2.ResourceType{
+		Id:          "capability",
+		DisplayName: "Capability",
+	}
+
+	// The access key resource type represents the API credentials of users and service accounts.
+	accessKeyResourceType = &v2.ResourceType{
+		Id:          "access_key",
+		DisplayName: "Access Key",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_SECRET},
+	}
+
+	// The account resource type represents the Sumo Logic organization, its owner is a single user.
+	accountResourceType = &v2.ResourceType{
+		Id:          "account",
+		DisplayName: "Account",
+	}
 )
@@ -0,0 +1,114 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+// roleMembershipIndex maps each role to the accounts holding it.
+// Users and service accounts already list their role IDs, so the index is built from a single pass over
+// the account listings instead of one GetRole call per role.
+type roleMembershipIndex struct {
+	includeUsers           bool
+	includeServiceAccounts bool
+
+	mu    sync.Mutex
+	built bool
+	roles map[string][]*v2.ResourceId
+}
+
+func newRoleMembershipIndex(includeUsers bool, includeServiceAccounts bool) *roleMembershipIndex {
+	return &roleMembershipIndex{
+		includeUsers:           includeUsers,
+		includeServiceAccounts: includeServiceAccounts,
+	}
+}
+
+// reset drops the index so the next lookup lists the accounts again.
+func (i *roleMembershipIndex) reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.built = false
+	i.roles = nil
+}
+
+// members returns the accounts holding the role, building the index on first use.
+func (i *roleMembershipIndex) members(ctx context.Context, service client.ClientService, roleID string) ([]*v2.ResourceId, annotations.Annotations, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	outputAnnotations := annotations.New()
+	if !i.built {
+		var err error
+		outputAnnotations, err = i.build(ctx, service)
+		if err != nil {
+			return nil, outputAnnotations, err
+		}
+	}
+
+	return i.roles[roleID], outputAnnotations, nil
+}
+
+// build lists the indexed kinds of accounts and records their role IDs.
+func (i *roleMembershipIndex) build(ctx context.Context, service client.ClientService) (annotations.Annotations, error) {
+	logger := ctxzap.Extract(ctx)
+	outputAnnotations := annotations.New()
+
+	roles := make(map[string][]*v2.ResourceId)
+	accounts := 0
+
+	var pageToken *string
+	for i.includeUsers {
+		users, nextPageToken, rateLimit, err := service.GetUsers(ctx, pageToken)
+		outputAnnotations.WithRateLimiting(rateLimit)
+		if err != nil {
+			return outputAnnotations, fmt.Errorf("failed to list users: %w", err)
+		}
+
+		for _, user := range users {
+			for _, roleID := range user.RoleIDs {
+				roles[roleID] = append(roles[roleID], &v2.ResourceId{ResourceType: userResourceType.Id, Resource: user.ID})
+			}
+		}
+		accounts += len(users)
+
+		if nextPageToken == nil || *nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
+	}
+
+	if i.includeServiceAccounts {
+		serviceAccounts, rateLimit, err := service.GetServiceAccounts(ctx)
+		outputAnnotations.WithRateLimiting(rateLimit)
+		if err != nil {
+			return outputAnnotations, fmt.Errorf("failed to list service accounts: %w", err)
+		}
+
+		for _, serviceAccount := range serviceAccounts {
+			for _, roleID := range serviceAccount.RoleIDs {
+				roles[roleID] = append(roles[roleID], &v2.ResourceId{ResourceType: serviceAccountResourceType.Id, Resource: serviceAccount.ID})
+			}
+		}
+		accounts += len(serviceAccounts)
+	}
+
+	logger.Debug(
+		"baton-sumo-logic: built role membership index",
+		zap.Int("accounts", accounts),
+		zap.Int("roles", len(roles)),
+	)
+
+	i.roles = roles
+	i.built = true
+
+	return outputAnnotations, nil
+}
@@ -3,6 +3,8 @@ package connector
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
@@ -13,12 +15,20 @@ import (
 	"github.com/conductorone/baton-sumo-logic/pkg/client"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const roleAssignmentEntitlement = "assigned"
 
 type roleBuilder struct {
 	service client.ClientService
+	// memberships is set when role grants are derived from the roles of users instead of one GetRole call per role.
+	memberships *roleMembershipIndex
+	// serviceAccountMemberships is set when service accounts are synced and roles are fetched individually.
+	// The members of a role may include service accounts, they are taken from this index instead so they are
+	// emitted with their own resource type rather than as users.
+	serviceAccountMemberships *roleMembershipIndex
 }
 
 func (o *roleBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
@@ -29,6 +39,15 @@ func (o *roleBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
 func (o *roleBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
 	outputAnnotations := annotations.New()
 
+	// A new sync starts by listing roles again, so memberships from a previous sync are dropped.
+	if pToken == nil || pToken.Token == "" {
+		for _, index := range []*roleMembershipIndex{o.memberships, o.serviceAccountMemberships} {
+			if index != nil {
+				index.reset()
+			}
+		}
+	}
+
 	roles, nextPageToken, rateLimit, err := o.service.GetRoles(ctx, parsePageToken(pToken))
 	outputAnnotations.WithRateLimiting(rateLimit)
 	if err != nil {
@@ -47,11 +66,30 @@ func (o *roleBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId,
 	return resources, createPageToken(nextPageToken), outputAnnotations, nil
 }
 
+// Get implements the ResourceTargetedSyncer interface.
+// The role is read without the cache so a targeted sync sees recent changes.
+func (o *roleBuilder) Get(ctx context.Context, resourceId *v2.ResourceId, _ *v2.ResourceId) (*v2.Resource, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	role, rateLimit, err := o.service.GetRole(client.WithoutCache(ctx), resourceId.Resource)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	roleResource, err := createRoleResource(role)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("failed to create role resource: %w", err)
+	}
+
+	return roleResource, outputAnnotations, nil
+}
+
 func (o *roleBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
 	var rv []*v2.Entitlement
 
 	assignmentOptions := []ent.EntitlementOption{
-		ent.WithGrantableTo(userResourceType),
+		ent.WithGrantableTo(userResourceType, serviceAccountResourceType),
 		ent.WithDisplayName(fmt.Sprintf("%s Role Member", resource.DisplayName)),
 		ent.WithDescription(fmt.Sprintf("Has the %s role in Sumo Logic", resource.DisplayName)),
 	}
@@ -62,6 +100,10 @@ func (o *roleBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *
 }
 
 func (o *roleBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	if o.memberships != nil {
+		return o.indexedGrants(ctx, resource)
+	}
+
 	outputAnnotations := annotations.New()
 	role, rateLimit, err := o.service.GetRole(ctx, resource.Id.Resource)
 	outputAnnotations.WithRateLimiting(rateLimit)
@@ -69,56 +111,170 @@ func (o *roleBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken
 		return nil, "", outputAnnotations, fmt.Errorf("failed to get role: %w", err)
 	}
 
-	if role.Users == nil {
-		return nil, "", outputAnnotations, nil
+	var serviceAccounts []*v2.ResourceId
+	if o.serviceAccountMemberships != nil {
+		var serviceAccountAnnotations annotations.Annotations
+		serviceAccounts, serviceAccountAnnotations, err = o.serviceAccountMemberships.members(ctx, o.service, role.ID)
+		outputAnnotations.Merge(serviceAccountAnnotations...)
+		if err != nil {
+			return nil, "", outputAnnotations, fmt.Errorf("failed to build service account role memberships: %w", err)
+		}
 	}
 
-	rv := make([]*v2.Grant, 0, len(*role.Users))
-	for _, userId := range *role.Users {
-		userResource := &v2.Resource{
+	// The members of a role may include service accounts, which are emitted with their own resource type.
+	members := make([]*v2.ResourceId, 0, len(serviceAccounts))
+	if role.Users != nil {
+		for _, userId := range *role.Users {
+			isServiceAccount := slices.ContainsFunc(serviceAccounts, func(serviceAccount *v2.ResourceId) bool {
+				return serviceAccount.Resource == userId
+			})
+			if !isServiceAccount {
+				members = append(members, &v2.ResourceId{ResourceType: userResourceType.Id, Resource: userId})
+			}
+		}
+	}
+	members = append(members, serviceAccounts...)
+
+	rv := roleMemberGrants(resource, members)
+	rv = append(rv, roleCapabilityGrants(resource, role)...)
+
+	return rv, "", outputAnnotations, nil
+}
+
+// indexedGrants serves the role grants from the membership index, and the capability grants from the role profile
+// written by List, so no role is fetched individually.
+func (o *roleBuilder) indexedGrants(ctx context.Context, resource *v2.Resource) ([]*v2.Grant, string, annotations.Annotations, error) {
+	members, outputAnnotations, err := o.memberships.members(ctx, o.service, resource.Id.Resource)
+	if err != nil {
+		return nil, "", outputAnnotations, fmt.Errorf("failed to build role memberships: %w", err)
+	}
+
+	rv := roleMemberGrants(resource, members)
+
+	roleTrait, err := rs.GetRoleTrait(resource)
+	if err != nil {
+		return rv, "", outputAnnotations, nil //nolint:nilerr // a role without a trait has no capabilities.
+	}
+
+	capabilities, err := profileStringList(roleTrait.GetProfile().AsMap(), "capabilities")
+	if err != nil {
+		return nil, "", outputAnnotations, fmt.Errorf("failed to read role capabilities: %w", err)
+	}
+
+	rv = append(rv, roleCapabilityGrants(resource, &client.RoleResponse{ID: resource.Id.Resource, Capabilities: &capabilities})...)
+
+	return rv, "", outputAnnotations, nil
+}
+
+// roleMemberGrants returns a grant on the role assignment entitlement for each user or service account.
+func roleMemberGrants(roleResource *v2.Resource, principals []*v2.ResourceId) []*v2.Grant {
+	rv := make([]*v2.Grant, 0, len(principals))
+	for _, principal := range principals {
+		rv = append(rv, grant.NewGrant(roleResource, roleAssignmentEntitlement, principal))
+	}
+
+	return rv
+}
+
+// roleCapabilityGrants returns a grant on each capability held by the role.
+// The grants are expandable through the role assignment entitlement so that role members inherit the capabilities.
+func roleCapabilityGrants(roleResource *v2.Resource, role *client.RoleResponse) []*v2.Grant {
+	if role.Capabilities == nil {
+		return nil
+	}
+
+	expandable := &v2.GrantExpandable{
+		EntitlementIds: []string{ent.NewEntitlementID(roleResource, roleAssignmentEntitlement)},
+	}
+
+	rv := make([]*v2.Grant, 0, len(*role.Capabilities))
+	for _, capability := range *role.Capabilities {
+		capabilityResource := &v2.Resource{
 			Id: &v2.ResourceId{
-				ResourceType: userResourceType.Id,
-				Resource:     userId,
+				ResourceType: capabilityResourceType.Id,
+				Resource:     capability,
 			},
 		}
 
-		rv = append(rv, grant.NewGrant(resource, roleAssignmentEntitlement, userResource))
+		rv = append(rv, grant.NewGrant(
+			capabilityResource,
+			capabilityGrantedEntitlement,
+			roleResource.Id,
+			grant.WithAnnotation(expandable),
+		))
 	}
 
-	return rv, "", outputAnnotations, nil
+	return rv
 }
 
+// Grant implements the ResourceProvisionerV2 interface.
+// It returns the created grant, so it is recorded without waiting for the next sync.
 func (o *roleBuilder) Grant(
 	ctx context.Context,
 	principal *v2.Resource,
 	entitlement *v2.Entitlement,
-) (annotations.Annotations, error) {
+) ([]*v2.Grant, annotations.Annotations, error) {
 	logger := ctxzap.Extract(ctx)
 
-	if principal.Id.ResourceType != userResourceType.Id {
+	outputAnnotations := annotations.New()
+	roleID := entitlement.Resource.Id.Resource
+
+	switch principal.Id.ResourceType {
+	case userResourceType.Id:
+	case serviceAccountResourceType.Id:
+		return o.grantServiceAccount(ctx, principal.Id, entitlement, outputAnnotations)
+	default:
 		logger.Error(
-			"baton-sumo-logic: only users can be assigned to a role",
+			"baton-sumo-logic: only users and service accounts can be assigned to a role",
 			zap.String("principal_type", principal.Id.ResourceType),
 			zap.String("principal_id", principal.Id.Resource),
 		)
-		return nil, fmt.Errorf("baton-sumo-logic: only users can be assigned to a role")
+		return nil, nil, fmt.Errorf("baton-sumo-logic: only users and service accounts can be assigned to a role")
 	}
 
-	outputAnnotations := annotations.New()
-	_, rateLimitData, err := o.service.AssignRoleToUser(
-		ctx,
-		entitlement.Resource.Id.Resource,
-		principal.Id.Resource,
-	)
+	// The API does not document an "already assigned" error, so the user's current roles are checked first.
+	// They are read without the cache, which may still hold the roles from the last sync.
+	// API Doc: https://api.sumologic.com/docs/#operation/assignRoleToUser
+	user, rateLimitData, err := o.service.GetUserByID(client.WithoutCache(ctx), principal.Id.Resource)
 	outputAnnotations.WithRateLimiting(rateLimitData)
+	if status.Code(err) == codes.NotFound {
+		// Service accounts were synced as users before they had their own resource type.
+		return o.grantServiceAccount(ctx, principal.Id, entitlement, outputAnnotations)
+	}
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get user: %w", err)
+	}
 
+	if slices.Contains(user.RoleIDs, roleID) {
+		logger.Info(
+			"baton-sumo-logic: role is already assigned to user",
+			zap.String("role_id", roleID),
+			zap.String("user_id", principal.Id.Resource),
+		)
+		outputAnnotations.Append(&v2.GrantAlreadyExists{})
+		return []*v2.Grant{grant.NewGrant(entitlement.Resource, roleAssignmentEntitlement, principal.Id)}, outputAnnotations, nil
+	}
+
+	role, rateLimitData, err := o.service.AssignRoleToUser(ctx, roleID, principal.Id.Resource)
+	outputAnnotations.WithRateLimiting(rateLimitData)
+	if status.Code(err) == codes.AlreadyExists {
+		outputAnnotations.Append(&v2.GrantAlreadyExists{})
+		return []*v2.Grant{grant.NewGrant(entitlement.Resource, roleAssignmentEntitlement, principal.Id)}, outputAnnotations, nil
+	}
 	if err != nil {
-		// We are not checking if the grant is already exists because the API DOC does not provide specific information.
-		// API Doc: https://api.sumologic.com/docs/#operation/assignRoleToUser
-		return outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to assign role to user: %w", err)
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to assign role to user: %w", err)
 	}
 
-	return outputAnnotations, nil
+	// The API returns the updated role, fall back to the entitlement resource if it is empty.
+	roleResource := entitlement.Resource
+	if role != nil && role.ID != "" {
+		roleResource, err = createRoleResource(role)
+		if err != nil {
+			return nil, outputAnnotations, fmt.Errorf("failed to create role resource: %w", err)
+		}
+	}
+
+	return []*v2.Grant{grant.NewGrant(roleResource, roleAssignmentEntitlement, principal.Id)}, outputAnnotations, nil
 }
 
 func (o *roleBuilder) Revoke(
@@ -130,37 +286,222 @@ func (o *roleBuilder) Revoke(
 ) {
 	logger := ctxzap.Extract(ctx)
 
-	if grant.Principal.Id.ResourceType != userResourceType.Id {
+	outputAnnotations := annotations.New()
+	roleID := grant.Entitlement.Resource.Id.Resource
+
+	switch grant.Principal.Id.ResourceType {
+	case userResourceType.Id:
+	case serviceAccountResourceType.Id:
+		return o.revokeServiceAccount(ctx, grant.Principal.Id, roleID, outputAnnotations)
+	default:
 		logger.Error(
-			"baton-sumo-logic: only users can be assigned to a role",
+			"baton-sumo-logic: only users and service accounts can be revoked from a role",
 			zap.String("principal_type", grant.Principal.Id.ResourceType),
 			zap.String("principal_id", grant.Principal.Id.Resource),
 		)
-		return nil, fmt.Errorf("baton-sumo-logic: only users can be revoked from a role")
+		return nil, fmt.Errorf("baton-sumo-logic: only users and service accounts can be revoked from a role")
 	}
 
-	outputAnnotations := annotations.New()
-
-	rateLimitData, err := o.service.RemoveRoleFromUser(
-		ctx,
-		grant.Entitlement.Resource.Id.Resource,
-		grant.Principal.Id.Resource,
-	)
+	// The API does not document an "already removed" error, so the user's current roles are checked first.
+	// They are read without the cache, which may still hold the roles from the last sync.
+	// API Doc: https://api.sumologic.com/docs/#operation/removeRoleFromUser
+	user, rateLimitData, err := o.service.GetUserByID(client.WithoutCache(ctx), grant.Principal.Id.Resource)
 	outputAnnotations.WithRateLimiting(rateLimitData)
+	if status.Code(err) == codes.NotFound {
+		// Service accounts were synced as users before they had their own resource type.
+		return o.revokeServiceAccount(ctx, grant.Principal.Id, roleID, outputAnnotations)
+	}
+	if err != nil {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get user: %w", err)
+	}
+
+	if !slices.Contains(user.RoleIDs, roleID) {
+		logger.Info(
+			"baton-sumo-logic: role is already removed from user",
+			zap.String("role_id", roleID),
+			zap.String("user_id", grant.Principal.Id.Resource),
+		)
+		outputAnnotations.Append(&v2.GrantAlreadyRevoked{})
+		return outputAnnotations, nil
+	}
 
+	rateLimitData, err = o.service.RemoveRoleFromUser(ctx, roleID, grant.Principal.Id.Resource)
+	outputAnnotations.WithRateLimiting(rateLimitData)
+	if status.Code(err) == codes.NotFound {
+		outputAnnotations.Append(&v2.GrantAlreadyRevoked{})
+		return outputAnnotations, nil
+	}
 	if err != nil {
-		// We are not checking if the grant was already revoked because the API DOC does not provide specific information.
-		// API Doc: https://api.sumologic.com/docs/#operation/assignRoleToUser
 		return outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to revoke role from user: %w", err)
 	}
 
 	return outputAnnotations, nil
 }
 
-func newRoleBuilder(cclient *client.Client) *roleBuilder {
-	return &roleBuilder{
+// grantServiceAccount adds the role to a service account.
+// Service accounts have no role assignment endpoint, so the account is rewritten with the new role.
+func (o *roleBuilder) grantServiceAccount(
+	ctx context.Context,
+	principalId *v2.ResourceId,
+	entitlement *v2.Entitlement,
+	outputAnnotations annotations.Annotations,
+) ([]*v2.Grant, annotations.Annotations, error) {
+	logger := ctxzap.Extract(ctx)
+	roleID := entitlement.Resource.Id.Resource
+
+	// The update rewrites the roles of the account, so they are read without the cache.
+	serviceAccount, rateLimitData, err := o.service.GetServiceAccountByID(client.WithoutCache(ctx), principalId.Resource)
+	outputAnnotations.WithRateLimiting(rateLimitData)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get user or service account: %w", err)
+	}
+
+	if slices.Contains(serviceAccount.RoleIDs, roleID) {
+		logger.Info(
+			"baton-sumo-logic: role is already assigned to service account",
+			zap.String("role_id", roleID),
+			zap.String("service_account_id", principalId.Resource),
+		)
+		outputAnnotations.Append(&v2.GrantAlreadyExists{})
+		return []*v2.Grant{grant.NewGrant(entitlement.Resource, roleAssignmentEntitlement, principalId)}, outputAnnotations, nil
+	}
+
+	serviceAccountRequest := client.ServiceAccountRequest{
+		Name:    serviceAccount.Name,
+		Email:   serviceAccount.Email,
+		RoleIDs: append(slices.Clone(serviceAccount.RoleIDs), roleID),
+	}
+
+	// API Doc: https://api.sumologic.com/docs/#operation/updateServiceAccount
+	_, rateLimitData, err = o.service.UpdateServiceAccount(ctx, serviceAccount.ID, serviceAccountRequest)
+	outputAnnotations.WithRateLimiting(rateLimitData)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to assign role to service account: %w", err)
+	}
+
+	return []*v2.Grant{grant.NewGrant(entitlement.Resource, roleAssignmentEntitlement, principalId)}, outputAnnotations, nil
+}
+
+// revokeServiceAccount removes the role from a service account by rewriting the account without it.
+func (o *roleBuilder) revokeServiceAccount(
+	ctx context.Context,
+	principalId *v2.ResourceId,
+	roleID string,
+	outputAnnotations annotations.Annotations,
+) (annotations.Annotations, error) {
+	logger := ctxzap.Extract(ctx)
+
+	serviceAccount, rateLimitData, err := o.service.GetServiceAccountByID(client.WithoutCache(ctx), principalId.Resource)
+	outputAnnotations.WithRateLimiting(rateLimitData)
+	if status.Code(err) == codes.NotFound {
+		outputAnnotations.Append(&v2.GrantAlreadyRevoked{})
+		return outputAnnotations, nil
+	}
+	if err != nil {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get service account: %w", err)
+	}
+
+	if !slices.Contains(serviceAccount.RoleIDs, roleID) {
+		logger.Info(
+			"baton-sumo-logic: role is already removed from service account",
+			zap.String("role_id", roleID),
+			zap.String("service_account_id", principalId.Resource),
+		)
+		outputAnnotations.Append(&v2.GrantAlreadyRevoked{})
+		return outputAnnotations, nil
+	}
+
+	serviceAccountRequest := client.ServiceAccountRequest{
+		Name:  serviceAccount.Name,
+		Email: serviceAccount.Email,
+		RoleIDs: slices.DeleteFunc(slices.Clone(serviceAccount.RoleIDs), func(id string) bool {
+			return id == roleID
+		}),
+	}
+
+	_, rateLimitData, err = o.service.UpdateServiceAccount(ctx, serviceAccount.ID, serviceAccountRequest)
+	outputAnnotations.WithRateLimiting(rateLimitData)
+	if err != nil {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to revoke role from service account: %w", err)
+	}
+
+	return outputAnnotations, nil
+}
+
+// Create implements the ResourceManager interface.
+// The role name is taken from the resource display name, and the capabilities and filter predicate from the role profile.
+func (o *roleBuilder) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	roleRequest, err := resourceToRoleRequest(resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outputAnnotations := annotations.New()
+	role, rateLimit, err := o.service.CreateRole(ctx, *roleRequest)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to create role: %w", err)
+	}
+
+	roleResource, err := createRoleResource(role)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("failed to create role resource: %w", err)
+	}
+
+	return roleResource, outputAnnotations, nil
+}
+
+// Delete implements the ResourceDeleter interface.
+// System defined roles cannot be deleted.
+func (o *roleBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	roleID := resourceId.GetResource()
+	if len(roleID) == 0 {
+		return nil, fmt.Errorf("missing resource ID")
+	}
+	l := ctxzap.Extract(ctx).With(zap.String("roleID", roleID))
+
+	outputAnnotations := annotations.New()
+	role, rateLimit, err := o.service.GetRole(client.WithoutCache(ctx), roleID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if status.Code(err) == codes.NotFound {
+		l.Info("baton-sumo-logic: delete-role: role was already deleted")
+		return outputAnnotations, nil
+	}
+	if err != nil {
+		l.Error("baton-sumo-logic: delete-role: failed to get role by ID", zap.Error(err))
+		return outputAnnotations, err
+	}
+
+	if role.SystemDefined != nil && *role.SystemDefined {
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: role %s is defined by the system and cannot be deleted", role.Name)
+	}
+
+	rateLimit, err = o.service.DeleteRole(ctx, role.ID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if status.Code(err) == codes.NotFound {
+		l.Info("baton-sumo-logic: delete-role: role was already deleted")
+		return outputAnnotations, nil
+	}
+	if err != nil {
+		l.Error("baton-sumo-logic: delete-role: failed to delete role", zap.Error(err))
+		return outputAnnotations, err
+	}
+
+	l.Info("baton-sumo-logic: delete-role: success")
+	return outputAnnotations, nil
+}
+
+func newRoleBuilder(cclient *client.Client, roleGrantsFromUsers bool, includeServiceAccounts bool) *roleBuilder {
+	builder := &roleBuilder{
 		service: client.NewClientService(cclient),
 	}
+	if roleGrantsFromUsers {
+		builder.memberships = newRoleMembershipIndex(true, includeServiceAccounts)
+	} else if includeServiceAccounts {
+		builder.serviceAccountMemberships = newRoleMembershipIndex(false, true)
+	}
+
+	return builder
 }
 
 func createRoleResource(role *client.RoleResponse) (*v2.Resource, error) {
@@ -179,6 +520,19 @@ func createRoleResource(role *client.RoleResponse) (*v2.Resource, error) {
 		"created_at":  role.CreatedAt,
 	}
 
+	if role.FilterPredicate != nil {
+		profile["filter_predicate"] = *role.FilterPredicate
+	}
+
+	// Data access filters are only returned by the v2 Roles API.
+	if role.DataFilters != nil {
+		addRoleDataFiltersToProfile(profile, role.DataFilters)
+	}
+
+	if role.Capabilities != nil {
+		profile["capabilities"] = strings.Join(*role.Capabilities, ",")
+	}
+
 	roleTraitOptions := []rs.RoleTraitOption{
 		rs.WithRoleProfile(profile),
 	}
@@ -195,3 +549,129 @@ func createRoleResource(role *client.RoleResponse) (*v2.Resource, error) {
 
 	return resource, nil
 }
+
+// roleResponseToRequest copies the settings of an existing role into an update request.
+// Sumo Logic replaces the whole role on update, so every field must be preserved.
+func roleResponseToRequest(role *client.RoleResponse) client.RoleRequest {
+	roleRequest := client.RoleRequest{
+		Name:                 role.Name,
+		Users:                []string{},
+		Capabilities:         []string{},
+		AutofillDependencies: role.AutofillDependencies,
+		DataFilters:          role.DataFilters,
+	}
+	if role.Description != nil {
+		roleRequest.Description = *role.Description
+	}
+	if role.FilterPredicate != nil {
+		roleRequest.FilterPredicate = *role.FilterPredicate
+	}
+	if role.Users != nil {
+		roleRequest.Users = append(roleRequest.Users, *role.Users...)
+	}
+	if role.Capabilities != nil {
+		roleRequest.Capabilities = append(roleRequest.Capabilities, *role.Capabilities...)
+	}
+
+	return roleRequest
+}
+
+// resourceToRoleRequest builds a create role request from a resource.
+// Capabilities may be given either as a list or as a comma separated string.
+func resourceToRoleRequest(resource *v2.Resource) (*client.RoleRequest, error) {
+	if resource.DisplayName == "" {
+		return nil, fmt.Errorf("missing role name")
+	}
+
+	roleRequest := &client.RoleRequest{
+		Name:         resource.DisplayName,
+		Description:  resource.Description,
+		Users:        []string{},
+		Capabilities: []string{},
+	}
+
+	roleTrait, err := rs.GetRoleTrait(resource)
+	if err != nil {
+		// A role without a trait has no capabilities or filter predicate.
+		return roleRequest, nil //nolint:nilerr // the role trait is optional on create.
+	}
+
+	pMap := roleTrait.GetProfile().AsMap()
+
+	if description, ok := pMap["description"].(string); ok && roleRequest.Description == "" {
+		roleRequest.Description = description
+	}
+
+	if filterPredicate, ok := pMap["filter_predicate"].(string); ok {
+		roleRequest.FilterPredicate = filterPredicate
+	}
+
+	roleRequest.DataFilters = profileToRoleDataFilters(pMap)
+
+	capabilities, err := profileStringList(pMap, "capabilities")
+	if err != nil {
+		return nil, err
+	}
+	roleRequest.Capabilities = append(roleRequest.Capabilities, capabilities...)
+
+	return roleRequest, nil
+}
+
+// addRoleDataFiltersToProfile writes the v2 data access filters into the role profile,
+// so reviewers can see which data each role can search.
+func addRoleDataFiltersToProfile(profile map[string]interface{}, filters *client.RoleDataFilters) {
+	if filters.LogAnalyticsFilter != nil {
+		profile["log_analytics_filter"] = *filters.LogAnalyticsFilter
+	}
+	if filters.AuditDataFilter != nil {
+		profile["audit_data_filter"] = *filters.AuditDataFilter
+	}
+	if filters.SecurityDataFilter != nil {
+		profile["security_data_filter"] = *filters.SecurityDataFilter
+	}
+	if filters.SelectionType != nil {
+		profile["selection_type"] = *filters.SelectionType
+	}
+	if filters.SelectedViews != nil {
+		views := make([]string, 0, len(*filters.SelectedViews))
+		for _, view := range *filters.SelectedViews {
+			views = append(views, view.ViewName)
+		}
+		profile["selected_views"] = strings.Join(views, ",")
+	}
+}
+
+// profileToRoleDataFilters reads the v2 data access filters from a role profile.
+// It returns nil when the profile holds none of them.
+func profileToRoleDataFilters(pMap map[string]interface{}) *client.RoleDataFilters {
+	filters := &client.RoleDataFilters{}
+	found := false
+
+	for key, target := range map[string]**string{
+		"log_analytics_filter": &filters.LogAnalyticsFilter,
+		"audit_data_filter":    &filters.AuditDataFilter,
+		"security_data_filter": &filters.SecurityDataFilter,
+		"selection_type":       &filters.SelectionType,
+	} {
+		if value, ok := pMap[key].(string); ok {
+			*target = &value
+			found = true
+		}
+	}
+
+	if views, ok := pMap["selected_views"].(string); ok && views != "" {
+		selectedViews := make([]client.RoleSelectedView, 0)
+		for _, view := range strings.Split(views, ",") {
+			if view = strings.TrimSpace(view); view != "" {
+				selectedViews = append(selectedViews, client.RoleSelectedView{ViewName: view})
+			}
+		}
+		filters.SelectedViews = &selectedViews
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+	return filters
+}
@@ -9,9 +9,12 @@ import (
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 	test "github.com/conductorone/baton-sdk/pkg/test"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
 	"github.com/conductorone/baton-sumo-logic/pkg/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -20,7 +23,7 @@ func newTestRoleBuilder() (*roleBuilder, *client.MockClientService) {
 	mockClient := &client.Client{}
 	mockClientService := &client.MockClientService{}
 
-	builder := newRoleBuilder(mockClient)
+	builder := newRoleBuilder(mockClient, false, false)
 	// Replace the service with our mock.
 	builder.service = mockClientService
 
@@ -129,6 +132,9 @@ func TestRoleGrantAndRevoke(t *testing.T) {
 
 	t.Run("Grant operation for role with valid principal and entitlement", func(t *testing.T) {
 		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return &client.UserResponse{BaseAccount: client.BaseAccount{ID: userId, RoleIDs: []string{"other-role"}}}, nil, nil
+		}
 		// Mock the add user to role call.
 		mockService.AssignRoleToUserFunc = func(ctx context.Context, roleId string, userId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
 			assert.Equal(t, "test-role", roleId)
@@ -153,10 +159,13 @@ func TestRoleGrantAndRevoke(t *testing.T) {
 		}
 
 		// Execute Grant.
-		_, err := roleBuilder.Grant(ctx, principal, entitlement)
+		grants, _, err := roleBuilder.Grant(ctx, principal, entitlement)
 
 		// Verify the result.
 		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		require.Equal(t, "test-role", grants[0].Entitlement.Resource.Id.Resource)
+		require.Equal(t, "test-user", grants[0].Principal.Id.Resource)
 	})
 
 	t.Run("Grant operation for role with invalid principal", func(t *testing.T) {
@@ -178,15 +187,18 @@ func TestRoleGrantAndRevoke(t *testing.T) {
 		}
 
 		// Execute Grant.
-		_, err := roleBuilder.Grant(ctx, principal, entitlement)
+		_, _, err := roleBuilder.Grant(ctx, principal, entitlement)
 
 		// Verify the error.
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "baton-sumo-logic: only users can be assigned to a role")
+		assert.Contains(t, err.Error(), "baton-sumo-logic: only users and service accounts can be assigned to a role")
 	})
 
 	t.Run("Revoke operation for role with valid principal and entitlement", func(t *testing.T) {
 		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return &client.UserResponse{BaseAccount: client.BaseAccount{ID: userId, RoleIDs: []string{"test-role"}}}, nil, nil
+		}
 		// Mock the remove user from role call.
 		mockService.RemoveRoleFromUserFunc = func(ctx context.Context, roleId string, userId string) (*v2.RateLimitDescription, error) {
 			assert.Equal(t, "test-role", roleId)
@@ -221,6 +233,62 @@ func TestRoleGrantAndRevoke(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("Grant operation returns the grant built from the updated role", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return &client.UserResponse{BaseAccount: client.BaseAccount{ID: userId}}, nil, nil
+		}
+		mockService.AssignRoleToUserFunc = func(ctx context.Context, roleId string, userId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			users := []string{userId}
+			return &client.RoleResponse{ID: roleId, Name: "baton-role", Users: &users}, nil, nil
+		}
+
+		principal := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "test-user"}}
+		entitlement := &v2.Entitlement{Resource: &v2.Resource{Id: &v2.ResourceId{ResourceType: roleResourceType.Id, Resource: "test-role"}}}
+
+		grants, _, err := roleBuilder.Grant(ctx, principal, entitlement)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		require.Equal(t, "role:test-role:assigned", grants[0].Entitlement.Id)
+		require.Equal(t, "baton-role", grants[0].Entitlement.Resource.DisplayName)
+	})
+
+	t.Run("Grant operation for role already assigned to the user", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return &client.UserResponse{BaseAccount: client.BaseAccount{ID: userId, RoleIDs: []string{"test-role"}}}, nil, nil
+		}
+		mockService.AssignRoleToUserFunc = func(ctx context.Context, roleId string, userId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			t.Fatal("AssignRoleToUser should not be called for an existing grant")
+			return nil, nil, nil
+		}
+
+		principal := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "test-user"}}
+		entitlement := &v2.Entitlement{Resource: &v2.Resource{Id: &v2.ResourceId{Resource: "test-role"}}}
+
+		_, outputAnnotations, err := roleBuilder.Grant(ctx, principal, entitlement)
+		require.NoError(t, err)
+		require.True(t, outputAnnotations.Contains(&v2.GrantAlreadyExists{}))
+	})
+
+	t.Run("Revoke operation for role already removed from the user", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return &client.UserResponse{BaseAccount: client.BaseAccount{ID: userId}}, nil, nil
+		}
+		mockService.RemoveRoleFromUserFunc = func(ctx context.Context, roleId string, userId string) (*v2.RateLimitDescription, error) {
+			t.Fatal("RemoveRoleFromUser should not be called for a revoked grant")
+			return nil, nil
+		}
+
+		principal := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "test-user"}}
+		entitlement := &v2.Entitlement{Resource: &v2.Resource{Id: &v2.ResourceId{Resource: "test-role"}}}
+
+		outputAnnotations, err := roleBuilder.Revoke(ctx, &v2.Grant{Principal: principal, Entitlement: entitlement})
+		require.NoError(t, err)
+		require.True(t, outputAnnotations.Contains(&v2.GrantAlreadyRevoked{}))
+	})
+
 	t.Run("Revoke operation for role with invalid principal", func(t *testing.T) {
 		roleBuilder, _ := newTestRoleBuilder()
 
@@ -249,6 +317,358 @@ func TestRoleGrantAndRevoke(t *testing.T) {
 
 		// Verify the error.
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "baton-sumo-logic: only users can be revoked from a role")
+		assert.Contains(t, err.Error(), "baton-sumo-logic: only users and service accounts can be revoked from a role")
+	})
+}
+
+func TestRoleCreateAndDelete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Create builds the role from the resource", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.CreateRoleFunc = func(ctx context.Context, roleRequest client.RoleRequest) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			assert.Equal(t, "project-role", roleRequest.Name)
+			assert.Equal(t, "Project role", roleRequest.Description)
+			assert.Equal(t, "_sourceCategory=project", roleRequest.FilterPredicate)
+			assert.Equal(t, []string{"viewCollectors", "manageContent"}, roleRequest.Capabilities)
+			return &client.RoleResponse{ID: "new-role", Name: roleRequest.Name}, nil, nil
+		}
+
+		resource, err := rs.NewRoleResource(
+			"project-role",
+			roleResourceType,
+			"",
+			[]rs.RoleTraitOption{rs.WithRoleProfile(map[string]interface{}{
+				"filter_predicate": "_sourceCategory=project",
+				"capabilities":     "viewCollectors, manageContent",
+			})},
+			rs.WithDescription("Project role"),
+		)
+		require.NoError(t, err)
+
+		created, _, err := roleBuilder.Create(ctx, resource)
+		require.NoError(t, err)
+		require.Equal(t, "new-role", created.Id.Resource)
+	})
+
+	t.Run("Delete removes a custom role", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetRoleFunc = func(ctx context.Context, roleId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			systemDefined := false
+			return &client.RoleResponse{ID: roleId, Name: "project-role", SystemDefined: &systemDefined}, nil, nil
+		}
+		deleted := false
+		mockService.DeleteRoleFunc = func(ctx context.Context, roleId string) (*v2.RateLimitDescription, error) {
+			assert.Equal(t, "test-role", roleId)
+			deleted = true
+			return nil, nil
+		}
+
+		_, err := roleBuilder.Delete(ctx, &v2.ResourceId{ResourceType: roleResourceType.Id, Resource: "test-role"})
+		require.NoError(t, err)
+		require.True(t, deleted)
+	})
+
+	t.Run("Delete refuses system defined roles", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetRoleFunc = func(ctx context.Context, roleId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			systemDefined := true
+			return &client.RoleResponse{ID: roleId, Name: "Administrator", SystemDefined: &systemDefined}, nil, nil
+		}
+
+		_, err := roleBuilder.Delete(ctx, &v2.ResourceId{ResourceType: roleResourceType.Id, Resource: "test-role"})
+		require.ErrorContains(t, err, "cannot be deleted")
+	})
+
+	t.Run("Delete succeeds when the role is deleted before the delete request", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetRoleFunc = func(ctx context.Context, roleId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			systemDefined := false
+			return &client.RoleResponse{ID: roleId, Name: "project-role", SystemDefined: &systemDefined}, nil, nil
+		}
+		mockService.DeleteRoleFunc = func(ctx context.Context, roleId string) (*v2.RateLimitDescription, error) {
+			return nil, status.Error(codes.NotFound, "role:not_found")
+		}
+
+		_, err := roleBuilder.Delete(ctx, &v2.ResourceId{ResourceType: roleResourceType.Id, Resource: "test-role"})
+		require.NoError(t, err)
+	})
+}
+
+func TestCreateRoleResourceDataFilters(t *testing.T) {
+	logAnalyticsFilter := "_sourceCategory=prod"
+	auditDataFilter := "_sourceCategory=audit"
+	securityDataFilter := ""
+	selectionType := "Allow"
+	selectedViews := []client.RoleSelectedView{{ViewName: "view-a"}, {ViewName: "view-b"}}
+
+	resource, err := createRoleResource(&client.RoleResponse{
+		ID:   "1",
+		Name: "baton-role",
+		DataFilters: &client.RoleDataFilters{
+			LogAnalyticsFilter: &logAnalyticsFilter,
+			AuditDataFilter:    &auditDataFilter,
+			SecurityDataFilter: &securityDataFilter,
+			SelectionType:      &selectionType,
+			SelectedViews:      &selectedViews,
+		},
+	})
+	require.NoError(t, err)
+
+	roleTrait, err := rs.GetRoleTrait(resource)
+	require.NoError(t, err)
+	profile := roleTrait.GetProfile().AsMap()
+	assert.Equal(t, logAnalyticsFilter, profile["log_analytics_filter"])
+	assert.Equal(t, auditDataFilter, profile["audit_data_filter"])
+	assert.Equal(t, securityDataFilter, profile["security_data_filter"])
+	assert.Equal(t, selectionType, profile["selection_type"])
+	assert.Equal(t, "view-a,view-b", profile["selected_views"])
+
+	// The same profile can be used to create a role with the v2 Roles API.
+	roleRequest, err := resourceToRoleRequest(resource)
+	require.NoError(t, err)
+	require.NotNil(t, roleRequest.DataFilters)
+	assert.Equal(t, logAnalyticsFilter, *roleRequest.DataFilters.LogAnalyticsFilter)
+	assert.Len(t, *roleRequest.DataFilters.SelectedViews, 2)
+}
+
+func TestRoleGet(t *testing.T) {
+	ctx := context.Background()
+
+	roleBuilder, mockClientService := newTestRoleBuilder()
+	mockClientService.GetRoleFunc = func(ctx context.Context, roleId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+		require.Equal(t, "role-1", roleId)
+		return &client.RoleResponse{ID: roleId, Name: "Administrator"}, nil, nil
+	}
+
+	resource, _, err := roleBuilder.Get(ctx, &v2.ResourceId{ResourceType: roleResourceType.Id, Resource: "role-1"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "role-1", resource.Id.Resource)
+	require.Equal(t, "Administrator", resource.DisplayName)
+}
+
+func TestRoleGrantsFromUsers(t *testing.T) {
+	ctx := context.Background()
+
+	newIndexedRoleBuilder := func(includeServiceAccounts bool) (*roleBuilder, *client.MockClientService) {
+		roleBuilder, mockClientService := newTestRoleBuilder()
+		roleBuilder.memberships = newRoleMembershipIndex(true, includeServiceAccounts)
+		mockClientService.GetRoleFunc = func(ctx context.Context, roleId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			t.Fatal("roles must not be fetched individually")
+			return nil, nil, nil
+		}
+		return roleBuilder, mockClientService
+	}
+
+	capabilities := []string{"manageUsersAndRoles"}
+	roleResource, err := createRoleResource(&client.RoleResponse{ID: "role-1", Name: "Administrator", Capabilities: &capabilities})
+	require.NoError(t, err)
+
+	t.Run("should build the index once from every user page and service account", func(t *testing.T) {
+		roleBuilder, mockClientService := newIndexedRoleBuilder(true)
+
+		userCalls := 0
+		nextToken := "page-2"
+		mockClientService.GetUsersFunc = func(ctx context.Context, pageToken *string) ([]*client.UserResponse, *string, *v2.RateLimitDescription, error) {
+			userCalls++
+			if pageToken == nil {
+				return []*client.UserResponse{
+					{BaseAccount: client.BaseAccount{ID: "user-1", RoleIDs: []string{"role-1", "role-2"}}},
+				}, &nextToken, nil, nil
+			}
+			return []*client.UserResponse{
+				{BaseAccount: client.BaseAccount{ID: "user-2", RoleIDs: []string{"role-2"}}},
+			}, nil, nil, nil
+		}
+		mockClientService.GetServiceAccountsFunc = func(ctx context.Context) ([]*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			return []*client.ServiceAccountResponse{
+				{BaseAccount: client.BaseAccount{ID: "service-1", RoleIDs: []string{"role-1"}}},
+			}, nil, nil
+		}
+
+		grants, _, _, err := roleBuilder.Grants(ctx, roleResource, &pagination.Token{})
+		require.NoError(t, err)
+		require.Len(t, grants, 3)
+
+		var principals []string
+		for _, g := range grants {
+			if g.Entitlement.Resource.Id.ResourceType == roleResourceType.Id {
+				principals = append(principals, g.Principal.Id.ResourceType+":"+g.Principal.Id.Resource)
+			}
+		}
+		assert.ElementsMatch(t, []string{"user:user-1", "service_account:service-1"}, principals)
+		require.Equal(t, capabilityResourceType.Id, grants[2].Entitlement.Resource.Id.ResourceType)
+
+		otherRole, err := createRoleResource(&client.RoleResponse{ID: "role-2", Name: "Analyst"})
+		require.NoError(t, err)
+		grants, _, _, err = roleBuilder.Grants(ctx, otherRole, &pagination.Token{})
+		require.NoError(t, err)
+		require.Len(t, grants, 2)
+		require.Equal(t, 2, userCalls)
+	})
+
+	t.Run("should skip service accounts when they are not synced", func(t *testing.T) {
+		roleBuilder, mockClientService := newIndexedRoleBuilder(false)
+
+		mockClientService.GetUsersFunc = func(ctx context.Context, pageToken *string) ([]*client.UserResponse, *string, *v2.RateLimitDescription, error) {
+			return []*client.UserResponse{
+				{BaseAccount: client.BaseAccount{ID: "user-1", RoleIDs: []string{"role-1"}}},
+			}, nil, nil, nil
+		}
+
+		grants, _, _, err := roleBuilder.Grants(ctx, roleResource, &pagination.Token{})
+		require.NoError(t, err)
+		require.Len(t, grants, 2)
+		require.Equal(t, "user-1", grants[0].Principal.Id.Resource)
+	})
+
+	t.Run("should rebuild the index when a new sync lists roles", func(t *testing.T) {
+		roleBuilder, mockClientService := newIndexedRoleBuilder(false)
+
+		userCalls := 0
+		mockClientService.GetUsersFunc = func(ctx context.Context, pageToken *string) ([]*client.UserResponse, *string, *v2.RateLimitDescription, error) {
+			userCalls++
+			return nil, nil, nil, nil
+		}
+		mockClientService.GetRolesFunc = func(ctx context.Context, pageToken *string) ([]*client.RoleResponse, *string, *v2.RateLimitDescription, error) {
+			return nil, nil, nil, nil
+		}
+
+		_, _, _, err := roleBuilder.Grants(ctx, roleResource, &pagination.Token{})
+		require.NoError(t, err)
+		_, _, _, err = roleBuilder.List(ctx, nil, &pagination.Token{})
+		require.NoError(t, err)
+		_, _, _, err = roleBuilder.Grants(ctx, roleResource, &pagination.Token{})
+		require.NoError(t, err)
+		require.Equal(t, 2, userCalls)
+	})
+}
+
+func TestServiceAccountRoles(t *testing.T) {
+	ctx := context.Background()
+
+	principal := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: serviceAccountResourceType.Id,
+			Resource:     "service-1",
+		},
+	}
+	entitlement := &v2.Entitlement{
+		Resource: &v2.Resource{
+			Id: &v2.ResourceId{
+				ResourceType: roleResourceType.Id,
+				Resource:     "test-role",
+			},
+		},
+	}
+
+	userNotFound := func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+		return nil, nil, status.Error(codes.NotFound, "user:not_found")
+	}
+	serviceAccount := func(roleIDs ...string) func(ctx context.Context, serviceAccountId string) (*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+		return func(ctx context.Context, serviceAccountId string) (*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			return &client.ServiceAccountResponse{
+				BaseAccount: client.BaseAccount{ID: serviceAccountId, Email: "bot@example.com", RoleIDs: roleIDs},
+				Name:        "bot",
+			}, nil, nil
+		}
+	}
+
+	t.Run("Grant adds the role to the service account", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetServiceAccountByIDFunc = serviceAccount("other-role")
+		mockService.UpdateServiceAccountFunc = func(
+			ctx context.Context,
+			serviceAccountId string,
+			serviceAccountRequest client.ServiceAccountRequest,
+		) (*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			require.Equal(t, "service-1", serviceAccountId)
+			require.Equal(t, "bot", serviceAccountRequest.Name)
+			require.Equal(t, "bot@example.com", serviceAccountRequest.Email)
+			require.Equal(t, []string{"other-role", "test-role"}, serviceAccountRequest.RoleIDs)
+			return nil, nil, nil
+		}
+
+		grants, _, err := roleBuilder.Grant(ctx, principal, entitlement)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		require.Equal(t, "service-1", grants[0].Principal.Id.Resource)
+	})
+
+	t.Run("Grant reports a role already held by the service account", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetServiceAccountByIDFunc = serviceAccount("test-role")
+
+		grants, outputAnnotations, err := roleBuilder.Grant(ctx, principal, entitlement)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		require.True(t, outputAnnotations.Contains(&v2.GrantAlreadyExists{}))
+	})
+
+	t.Run("Revoke removes the role from the service account", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetServiceAccountByIDFunc = serviceAccount("other-role", "test-role")
+		mockService.UpdateServiceAccountFunc = func(
+			ctx context.Context,
+			serviceAccountId string,
+			serviceAccountRequest client.ServiceAccountRequest,
+		) (*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			require.Equal(t, []string{"other-role"}, serviceAccountRequest.RoleIDs)
+			return nil, nil, nil
+		}
+
+		_, err := roleBuilder.Revoke(ctx, &v2.Grant{Principal: principal, Entitlement: entitlement})
+		require.NoError(t, err)
+	})
+
+	t.Run("Revoke falls back to service accounts synced as users", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetUserByIDFunc = userNotFound
+		mockService.GetServiceAccountByIDFunc = func(ctx context.Context, serviceAccountId string) (*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			return nil, nil, status.Error(codes.NotFound, "service_account:not_found")
+		}
+
+		legacyPrincipal := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "service-1"}}
+		outputAnnotations, err := roleBuilder.Revoke(ctx, &v2.Grant{Principal: legacyPrincipal, Entitlement: entitlement})
+		require.NoError(t, err)
+		require.True(t, outputAnnotations.Contains(&v2.GrantAlreadyRevoked{}))
+	})
+
+	t.Run("Revoke succeeds when the service account does not exist", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		mockService.GetServiceAccountByIDFunc = func(ctx context.Context, serviceAccountId string) (*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			return nil, nil, status.Error(codes.NotFound, "service_account:not_found")
+		}
+
+		outputAnnotations, err := roleBuilder.Revoke(ctx, &v2.Grant{Principal: principal, Entitlement: entitlement})
+		require.NoError(t, err)
+		require.True(t, outputAnnotations.Contains(&v2.GrantAlreadyRevoked{}))
+	})
+
+	t.Run("Grants include the service accounts holding the role", func(t *testing.T) {
+		roleBuilder, mockService := newTestRoleBuilder()
+		roleBuilder.serviceAccountMemberships = newRoleMembershipIndex(false, true)
+		mockService.GetRoleFunc = func(ctx context.Context, roleId string) (*client.RoleResponse, *v2.RateLimitDescription, error) {
+			users := []string{"user-1", "service-1"}
+			return &client.RoleResponse{ID: roleId, Users: &users}, nil, nil
+		}
+		mockService.GetServiceAccountsFunc = func(ctx context.Context) ([]*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			return []*client.ServiceAccountResponse{
+				{BaseAccount: client.BaseAccount{ID: "service-1", RoleIDs: []string{"test-role"}}},
+				{BaseAccount: client.BaseAccount{ID: "service-2", RoleIDs: []string{"test-role"}}},
+			}, nil, nil
+		}
+
+		roleResource, err := createRoleResource(&client.RoleResponse{ID: "test-role", Name: "Test Role"})
+		require.NoError(t, err)
+
+		grants, _, _, err := roleBuilder.Grants(ctx, roleResource, &pagination.Token{})
+		require.NoError(t, err)
+
+		principals := make([]string, 0, len(grants))
+		for _, g := range grants {
+			principals = append(principals, g.Principal.Id.ResourceType+":"+g.Principal.Id.Resource)
+		}
+		require.Equal(t, []string{"user:user-1", "service_account:service-1", "service_account:service-2"}, principals)
 	})
 }
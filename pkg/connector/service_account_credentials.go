@@ -0,0 +1,132 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RotateCapabilityDetails implements the CredentialManager interface.
+// Sumo Logic generates the secret of every access key, which is reported as a random password.
+func (o *serviceAccountBuilder) RotateCapabilityDetails(_ context.Context) (*v2.CredentialDetailsCredentialRotation, annotations.Annotations, error) {
+	return &v2.CredentialDetailsCredentialRotation{
+		SupportedCredentialOptions: []v2.CapabilityDetailCredentialOption{
+			v2.CapabilityDetailCredentialOption_CAPABILITY_DETAIL_CREDENTIAL_OPTION_RANDOM_PASSWORD,
+		},
+		PreferredCredentialOption: v2.CapabilityDetailCredentialOption_CAPABILITY_DETAIL_CREDENTIAL_OPTION_RANDOM_PASSWORD,
+	}, nil, nil
+}
+
+// Rotate implements the CredentialManager interface.
+// A new access key is created for the service account and returned, the SDK encrypts it.
+// Without a rotation overlap, the key being replaced is disabled then deleted right away. Sumo Logic does not
+// record which key a rotation replaces, so that is only done when the service account had a single other key.
+// Otherwise the previous keys keep working, and are retired with the retire_access_key action.
+func (o *serviceAccountBuilder) Rotate(
+	ctx context.Context,
+	resourceId *v2.ResourceId,
+	credentialOptions *v2.CredentialOptions,
+) ([]*v2.PlaintextData, annotations.Annotations, error) {
+	if resourceId.GetResourceType() != serviceAccountResourceType.Id {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: only service account credentials can be rotated")
+	}
+	if credentialOptions.GetNoPassword() != nil || credentialOptions.GetSso() != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: service account access keys only support random credentials")
+	}
+
+	serviceAccountID := resourceId.GetResource()
+	l := ctxzap.Extract(ctx).With(zap.String("serviceAccountID", serviceAccountID))
+
+	outputAnnotations := annotations.New()
+	serviceAccount, rateLimit, err := o.service.GetServiceAccountByID(ctx, serviceAccountID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get service account: %w", err)
+	}
+
+	// A cached listing could miss keys created since the last sync.
+	previousKeys, listAnnotations, err := serviceAccountAccessKeys(client.WithoutCache(ctx), o.service, serviceAccount.ID)
+	outputAnnotations.Merge(listAnnotations...)
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	label := fmt.Sprintf("%s rotated %s", serviceAccount.Name, time.Now().UTC().Format(time.RFC3339))
+	accessKey, rateLimit, err := o.service.CreateServiceAccountAccessKey(ctx, serviceAccount.ID, label)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to create access key: %w", err)
+	}
+	l.Info("baton-sumo-logic: rotate-credentials: created access key", zap.String("accessKeyID", accessKey.ID))
+
+	if o.accessKeyRotationOverlap == 0 && len(previousKeys) == 1 && !isConnectorAccessKey(o.apiAccessID, previousKeys[0].ID) {
+		replacedKey := previousKeys[0]
+		retireAnnotations, err := retireAccessKey(ctx, o.service, replacedKey)
+		outputAnnotations.Merge(retireAnnotations...)
+		if err != nil {
+			// The new key is already created, it is still returned so it is not lost.
+			l.Error("baton-sumo-logic: rotate-credentials: failed to retire access key", zap.String("accessKeyID", replacedKey.ID), zap.Error(err))
+		} else {
+			l.Info("baton-sumo-logic: rotate-credentials: retired access key", zap.String("accessKeyID", replacedKey.ID))
+		}
+	} else if len(previousKeys) > 0 {
+		l.Info(
+			"baton-sumo-logic: rotate-credentials: kept the previous access keys, retire them with the retire_access_key action",
+			zap.Int("previousKeys", len(previousKeys)),
+		)
+	}
+
+	return []*v2.PlaintextData{
+		{
+			Name:        "access_id",
+			Description: "The access ID of the Sumo Logic access key.",
+			Bytes:       []byte(accessKey.ID),
+		},
+		{
+			Name:        "access_key",
+			Description: "The Sumo Logic access key.",
+			Bytes:       []byte(accessKey.Key),
+		},
+	}, outputAnnotations, nil
+}
+
+// serviceAccountAccessKeys returns the access keys of a service account.
+// Access keys only record the account that created them, so the keys of a service account are taken to be the ones
+// whose CreatedBy is its ID. Sumo Logic does not document this for service accounts, it is what the keys created
+// with CreateServiceAccountAccessKey are expected to report.
+func serviceAccountAccessKeys(
+	ctx context.Context,
+	service client.ClientService,
+	serviceAccountID string,
+) ([]*client.AccessKeyResponse, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	var rv []*client.AccessKeyResponse
+	var pageToken *string
+	for {
+		accessKeys, nextPageToken, rateLimit, err := service.GetAccessKeys(ctx, pageToken)
+		outputAnnotations.WithRateLimiting(rateLimit)
+		if err != nil {
+			return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to list access keys: %w", err)
+		}
+
+		for _, accessKey := range accessKeys {
+			if accessKey.CreatedBy == serviceAccountID {
+				rv = append(rv, accessKey)
+			}
+		}
+
+		if nextPageToken == nil || *nextPageToken == "" {
+			return rv, outputAnnotations, nil
+		}
+		pageToken = nextPageToken
+	}
+}
@@ -0,0 +1,217 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const serviceAccountOwnerEntitlement = "owner"
+
+type serviceAccountBuilder struct {
+	service     client.ClientService
+	apiAccessID string
+	// accessKeyRotationOverlap is how long a rotated access key keeps working next to its successor.
+	accessKeyRotationOverlap time.Duration
+}
+
+func (o *serviceAccountBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return serviceAccountResourceType
+}
+
+// List returns all the service accounts from Sumo Logic as resource objects.
+// The service accounts endpoint does not support pagination, so they are returned in a single page.
+func (o *serviceAccountBuilder) List(ctx context.Context, _ *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	serviceAccounts, rateLimit, err := o.service.GetServiceAccounts(ctx)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, "", outputAnnotations, fmt.Errorf("failed to get service accounts: %w", err)
+	}
+
+	resources := make([]*v2.Resource, 0, len(serviceAccounts))
+	for _, serviceAccount := range serviceAccounts {
+		serviceAccountResource, err := createUserResource(serviceAccount)
+		if err != nil {
+			return nil, "", outputAnnotations, fmt.Errorf("failed to create service account resource: %w", err)
+		}
+		resources = append(resources, serviceAccountResource)
+	}
+
+	return resources, "", outputAnnotations, nil
+}
+
+// Get implements the ResourceTargetedSyncer interface, reading the service account without the cache.
+func (o *serviceAccountBuilder) Get(ctx context.Context, resourceId *v2.ResourceId, _ *v2.ResourceId) (*v2.Resource, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	serviceAccount, rateLimit, err := o.service.GetServiceAccountByID(client.WithoutCache(ctx), resourceId.Resource)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	serviceAccountResource, err := createUserResource(serviceAccount)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("failed to create service account resource: %w", err)
+	}
+
+	return serviceAccountResource, outputAnnotations, nil
+}
+
+// Entitlements returns the owner entitlement, held by the user who created the service account.
+func (o *serviceAccountBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+
+	ownerOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(userResourceType),
+		ent.WithDisplayName(fmt.Sprintf("%s Service Account Owner", resource.DisplayName)),
+		ent.WithDescription(fmt.Sprintf("Created the %s service account in Sumo Logic", resource.DisplayName)),
+	}
+
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, serviceAccountOwnerEntitlement, ownerOptions...))
+
+	return rv, "", nil, nil
+}
+
+// Grants links the service account to the user who created it.
+// The creator is read from the resource profile, so no API call is needed.
+func (o *serviceAccountBuilder) Grants(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	userTrait, err := rs.GetUserTrait(resource)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to get service account trait: %w", err)
+	}
+
+	createdBy, ok := rs.GetProfileStringValue(userTrait.GetProfile(), "created_by")
+	if !ok || createdBy == "" {
+		return nil, "", nil, nil
+	}
+
+	creator := &v2.ResourceId{
+		ResourceType: userResourceType.Id,
+		Resource:     createdBy,
+	}
+
+	return []*v2.Grant{grant.NewGrant(resource, serviceAccountOwnerEntitlement, creator)}, "", nil, nil
+}
+
+// Create implements the ResourceManager interface.
+// The name is taken from the resource display name, the email from the user trait and the role IDs from the profile.
+func (o *serviceAccountBuilder) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	serviceAccountRequest, err := resourceToServiceAccountRequest(resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outputAnnotations := annotations.New()
+	serviceAccount, rateLimit, err := o.service.CreateServiceAccount(ctx, *serviceAccountRequest)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to create service account: %w", err)
+	}
+
+	serviceAccountResource, err := createUserResource(serviceAccount)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("failed to create service account resource: %w", err)
+	}
+
+	return serviceAccountResource, outputAnnotations, nil
+}
+
+// Delete implements the ResourceDeleter interface.
+func (o *serviceAccountBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	serviceAccountID := resourceId.GetResource()
+	if len(serviceAccountID) == 0 {
+		return nil, fmt.Errorf("missing resource ID")
+	}
+	l := ctxzap.Extract(ctx).With(zap.String("serviceAccountID", serviceAccountID))
+
+	outputAnnotations := annotations.New()
+	serviceAccount, rateLimit, err := o.service.GetServiceAccountByID(ctx, serviceAccountID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if status.Code(err) == codes.NotFound {
+		l.Info("baton-sumo-logic: delete-service-account: service account was already deleted")
+		return outputAnnotations, nil
+	}
+	if err != nil {
+		l.Error("baton-sumo-logic: delete-service-account: failed to get service account by ID", zap.Error(err))
+		return outputAnnotations, err
+	}
+
+	rateLimit, err = o.service.DeleteServiceAccount(ctx, serviceAccount.ID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if status.Code(err) == codes.NotFound {
+		l.Info("baton-sumo-logic: delete-service-account: service account was already deleted")
+		return outputAnnotations, nil
+	}
+	if err != nil {
+		l.Error("baton-sumo-logic: delete-service-account: failed to delete service account", zap.Error(err))
+		return outputAnnotations, err
+	}
+
+	l.Info("baton-sumo-logic: delete-service-account: success")
+	return outputAnnotations, nil
+}
+
+func newServiceAccountBuilder(cclient *client.Client, apiAccessID string, accessKeyRotationOverlap time.Duration) *serviceAccountBuilder {
+	return &serviceAccountBuilder{
+		service:                  client.NewClientService(cclient),
+		apiAccessID:              apiAccessID,
+		accessKeyRotationOverlap: accessKeyRotationOverlap,
+	}
+}
+
+// resourceToServiceAccountRequest builds a create service account request from a resource.
+// Role IDs may be given either as a list or as a comma separated string.
+func resourceToServiceAccountRequest(resource *v2.Resource) (*client.ServiceAccountRequest, error) {
+	if resource.DisplayName == "" {
+		return nil, fmt.Errorf("missing service account name")
+	}
+
+	userTrait, err := rs.GetUserTrait(resource)
+	if err != nil {
+		return nil, fmt.Errorf("missing service account email and roles: %w", err)
+	}
+
+	pMap := userTrait.GetProfile().AsMap()
+
+	var email string
+	for _, e := range userTrait.GetEmails() {
+		if e.GetAddress() != "" && (email == "" || e.GetIsPrimary()) {
+			email = e.GetAddress()
+		}
+	}
+	if email == "" {
+		email, _ = pMap["email"].(string)
+	}
+	if email == "" {
+		return nil, fmt.Errorf("missing service account email")
+	}
+
+	roleIDs, err := profileStringList(pMap, "role_ids")
+	if err != nil {
+		return nil, err
+	}
+	if len(roleIDs) == 0 {
+		return nil, fmt.Errorf("missing service account role IDs")
+	}
+
+	return &client.ServiceAccountRequest{
+		Name:    resource.DisplayName,
+		Email:   email,
+		RoleIDs: roleIDs,
+	}, nil
+}
@@ -0,0 +1,341 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	test "github.com/conductorone/baton-sdk/pkg/test"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Helper function to create a test builder with mocks.
+func newTestServiceAccountBuilder() (*serviceAccountBuilder, *client.MockClientService) {
+	mockClient := &client.Client{}
+	mockClientService := &client.MockClientService{}
+
+	builder := newServiceAccountBuilder(mockClient, "connector-key", 0)
+	// Replace the service with our mock.
+	builder.service = mockClientService
+
+	return builder, mockClientService
+}
+
+func newTestServiceAccount(id string) *client.ServiceAccountResponse {
+	isActive := true
+	return &client.ServiceAccountResponse{
+		BaseAccount: client.BaseAccount{
+			ID:         id,
+			Email:      "baton-service-account@conductorone.com",
+			IsActive:   &isActive,
+			CreatedAt:  time.Now(),
+			CreatedBy:  "creator-1",
+			ModifiedBy: "creator-1",
+			ModifiedAt: time.Now(),
+			RoleIDs:    []string{"role-1", "role-2"},
+		},
+		Name: "baton-service-account",
+	}
+}
+
+func TestServiceAccountsList(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should get ratelimit annotations", func(t *testing.T) {
+		serviceAccountBuilder, mockClientService := newTestServiceAccountBuilder()
+
+		mockClientService.GetServiceAccountsFunc = func(ctx context.Context) ([]*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			rateLimitData := v2.RateLimitDescription{
+				ResetAt: timestamppb.New(time.Now().Add(10 * time.Second)),
+			}
+			return nil, &rateLimitData, fmt.Errorf("ratelimit error")
+		}
+
+		resources, token, annotations, err := serviceAccountBuilder.List(ctx, nil, &pagination.Token{})
+
+		require.Nil(t, resources)
+		require.Empty(t, token)
+		require.NotNil(t, err)
+
+		// There should be annotations.
+		require.Len(t, annotations, 1)
+		rateLimitData := v2.RateLimitDescription{}
+		err = annotations[0].UnmarshalTo(&rateLimitData)
+		require.NoError(t, err)
+		require.NotNil(t, rateLimitData.ResetAt)
+	})
+
+	t.Run("should get service accounts with their own resource type", func(t *testing.T) {
+		serviceAccountBuilder, mockClientService := newTestServiceAccountBuilder()
+
+		mockClientService.GetServiceAccountsFunc = func(ctx context.Context) ([]*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			return []*client.ServiceAccountResponse{newTestServiceAccount("1")}, nil, nil
+		}
+
+		resources, token, annotations, err := serviceAccountBuilder.List(ctx, nil, &pagination.Token{})
+		require.NoError(t, err)
+		require.Empty(t, token)
+		test.AssertNoRatelimitAnnotations(t, annotations)
+		require.Len(t, resources, 1)
+		require.Equal(t, serviceAccountResourceType.Id, resources[0].Id.ResourceType)
+		require.Equal(t, "baton-service-account", resources[0].DisplayName)
+
+		userTrait, err := rs.GetUserTrait(resources[0])
+		require.NoError(t, err)
+		require.Equal(t, v2.UserTrait_ACCOUNT_TYPE_SERVICE, userTrait.AccountType)
+
+		profile := userTrait.GetProfile().AsMap()
+		require.Equal(t, "baton-service-account", profile["name"])
+		require.Equal(t, "baton-service-account@conductorone.com", profile["email"])
+		require.Equal(t, "creator-1", profile["created_by"])
+		require.Equal(t, "role-1,role-2", profile["role_ids"])
+	})
+}
+
+func TestServiceAccountGet(t *testing.T) {
+	ctx := context.Background()
+
+	serviceAccountBuilder, mockClientService := newTestServiceAccountBuilder()
+	mockClientService.GetServiceAccountByIDFunc = func(ctx context.Context, serviceAccountId string) (*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+		require.Equal(t, "1", serviceAccountId)
+		return newTestServiceAccount(serviceAccountId), nil, nil
+	}
+
+	resource, _, err := serviceAccountBuilder.Get(ctx, &v2.ResourceId{ResourceType: serviceAccountResourceType.Id, Resource: "1"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, serviceAccountResourceType.Id, resource.Id.ResourceType)
+	require.Equal(t, "1", resource.Id.Resource)
+}
+
+func TestServiceAccountOwnerGrants(t *testing.T) {
+	ctx := context.Background()
+	serviceAccountBuilder, _ := newTestServiceAccountBuilder()
+
+	t.Run("should grant the owner entitlement to the creator", func(t *testing.T) {
+		resource, err := createUserResource(newTestServiceAccount("1"))
+		require.NoError(t, err)
+
+		grants, _, _, err := serviceAccountBuilder.Grants(ctx, resource, &pagination.Token{})
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		require.Equal(t, "service_account:1:owner", grants[0].Entitlement.Id)
+		require.Equal(t, userResourceType.Id, grants[0].Principal.Id.ResourceType)
+		require.Equal(t, "creator-1", grants[0].Principal.Id.Resource)
+	})
+
+	t.Run("should skip service accounts without a creator", func(t *testing.T) {
+		serviceAccount := newTestServiceAccount("1")
+		serviceAccount.CreatedBy = ""
+		resource, err := createUserResource(serviceAccount)
+		require.NoError(t, err)
+
+		grants, _, _, err := serviceAccountBuilder.Grants(ctx, resource, &pagination.Token{})
+		require.NoError(t, err)
+		require.Empty(t, grants)
+	})
+}
+
+func TestServiceAccountCreateAndDelete(t *testing.T) {
+	ctx := context.Background()
+
+	newServiceAccountResource := func(t *testing.T, profile map[string]interface{}) *v2.Resource {
+		resource, err := rs.NewUserResource(
+			"pipeline-bot",
+			serviceAccountResourceType,
+			"",
+			[]rs.UserTraitOption{
+				rs.WithEmail("pipeline-bot@example.com", true),
+				rs.WithUserProfile(profile),
+			},
+		)
+		require.NoError(t, err)
+		return resource
+	}
+
+	t.Run("Create builds the service account from the resource", func(t *testing.T) {
+		serviceAccountBuilder, mockClientService := newTestServiceAccountBuilder()
+		mockClientService.CreateServiceAccountFunc = func(
+			ctx context.Context,
+			serviceAccountRequest client.ServiceAccountRequest,
+		) (*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			require.Equal(t, "pipeline-bot", serviceAccountRequest.Name)
+			require.Equal(t, "pipeline-bot@example.com", serviceAccountRequest.Email)
+			require.Equal(t, []string{"role-1", "role-2"}, serviceAccountRequest.RoleIDs)
+
+			serviceAccount := newTestServiceAccount("new-id")
+			serviceAccount.Name = serviceAccountRequest.Name
+			return serviceAccount, nil, nil
+		}
+
+		resource := newServiceAccountResource(t, map[string]interface{}{"role_ids": "role-1, role-2"})
+		created, _, err := serviceAccountBuilder.Create(ctx, resource)
+		require.NoError(t, err)
+		require.Equal(t, serviceAccountResourceType.Id, created.Id.ResourceType)
+		require.Equal(t, "new-id", created.Id.Resource)
+	})
+
+	t.Run("Create requires role IDs", func(t *testing.T) {
+		serviceAccountBuilder, _ := newTestServiceAccountBuilder()
+
+		resource := newServiceAccountResource(t, map[string]interface{}{})
+		_, _, err := serviceAccountBuilder.Create(ctx, resource)
+		require.ErrorContains(t, err, "missing service account role IDs")
+	})
+
+	t.Run("Delete removes the service account", func(t *testing.T) {
+		serviceAccountBuilder, mockClientService := newTestServiceAccountBuilder()
+		mockClientService.GetServiceAccountByIDFunc = func(ctx context.Context, serviceAccountId string) (*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			return newTestServiceAccount(serviceAccountId), nil, nil
+		}
+		deleted := false
+		mockClientService.DeleteServiceAccountFunc = func(ctx context.Context, serviceAccountId string) (*v2.RateLimitDescription, error) {
+			require.Equal(t, "1", serviceAccountId)
+			deleted = true
+			return nil, nil
+		}
+
+		_, err := serviceAccountBuilder.Delete(ctx, &v2.ResourceId{ResourceType: serviceAccountResourceType.Id, Resource: "1"})
+		require.NoError(t, err)
+		require.True(t, deleted)
+	})
+
+	t.Run("Delete succeeds when the service account was already deleted", func(t *testing.T) {
+		serviceAccountBuilder, mockClientService := newTestServiceAccountBuilder()
+		mockClientService.GetServiceAccountByIDFunc = func(ctx context.Context, serviceAccountId string) (*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			return nil, nil, status.Error(codes.NotFound, "service_account:not_found")
+		}
+
+		_, err := serviceAccountBuilder.Delete(ctx, &v2.ResourceId{ResourceType: serviceAccountResourceType.Id, Resource: "1"})
+		require.NoError(t, err)
+	})
+}
+
+func TestServiceAccountRotate(t *testing.T) {
+	ctx := context.Background()
+	serviceAccountID := &v2.ResourceId{ResourceType: serviceAccountResourceType.Id, Resource: "1"}
+
+	newRotateBuilder := func(overlap time.Duration, accessKeys []*client.AccessKeyResponse) (*serviceAccountBuilder, *client.MockClientService) {
+		serviceAccountBuilder, mockClientService := newTestServiceAccountBuilder()
+		serviceAccountBuilder.accessKeyRotationOverlap = overlap
+
+		mockClientService.GetServiceAccountByIDFunc = func(ctx context.Context, serviceAccountId string) (*client.ServiceAccountResponse, *v2.RateLimitDescription, error) {
+			return newTestServiceAccount(serviceAccountId), nil, nil
+		}
+		mockClientService.GetAccessKeysFunc = func(ctx context.Context, pageToken *string) ([]*client.AccessKeyResponse, *string, *v2.RateLimitDescription, error) {
+			return accessKeys, nil, nil, nil
+		}
+		mockClientService.CreateServiceAccountAccessKeyFunc = func(
+			ctx context.Context,
+			serviceAccountId string,
+			label string,
+		) (*client.AccessKeyCreateResponse, *v2.RateLimitDescription, error) {
+			require.Equal(t, "1", serviceAccountId)
+			require.Contains(t, label, "baton-service-account")
+			return &client.AccessKeyCreateResponse{
+				AccessKeyResponse: client.AccessKeyResponse{ID: "new-key", CreatedBy: serviceAccountId, CreatedAt: time.Now()},
+				Key:               "new-secret",
+			}, nil, nil
+		}
+
+		return serviceAccountBuilder, mockClientService
+	}
+
+	// recordRetired records the keys disabled then deleted by a rotation.
+	recordRetired := func(t *testing.T, mockClientService *client.MockClientService) *[]string {
+		var retired []string
+		disabled := make(map[string]bool)
+		mockClientService.UpdateAccessKeyFunc = func(
+			ctx context.Context,
+			accessKeyId string,
+			accessKeyRequest client.AccessKeyUpdateRequest,
+		) (*client.AccessKeyResponse, *v2.RateLimitDescription, error) {
+			require.True(t, accessKeyRequest.Disabled)
+			disabled[accessKeyId] = true
+			return &client.AccessKeyResponse{ID: accessKeyId, Disabled: true}, nil, nil
+		}
+		mockClientService.DeleteAccessKeyFunc = func(ctx context.Context, accessKeyId string) (*v2.RateLimitDescription, error) {
+			require.True(t, disabled[accessKeyId], "access key must be disabled before it is deleted")
+			retired = append(retired, accessKeyId)
+			return nil, nil
+		}
+		return &retired
+	}
+
+	t.Run("should return the new access key", func(t *testing.T) {
+		serviceAccountBuilder, mockClientService := newRotateBuilder(0, nil)
+		recordRetired(t, mockClientService)
+
+		plaintexts, _, err := serviceAccountBuilder.Rotate(ctx, serviceAccountID, &v2.CredentialOptions{
+			Options: &v2.CredentialOptions_RandomPassword_{RandomPassword: &v2.CredentialOptions_RandomPassword{}},
+		})
+		require.NoError(t, err)
+		require.Len(t, plaintexts, 2)
+		require.Equal(t, "access_id", plaintexts[0].Name)
+		require.Equal(t, []byte("new-key"), plaintexts[0].Bytes)
+		require.Equal(t, "access_key", plaintexts[1].Name)
+		require.Equal(t, []byte("new-secret"), plaintexts[1].Bytes)
+	})
+
+	t.Run("should retire the replaced key without overlap", func(t *testing.T) {
+		serviceAccountBuilder, mockClientService := newRotateBuilder(0, []*client.AccessKeyResponse{
+			{ID: "old-key", CreatedBy: "1", CreatedAt: time.Now().Add(-time.Hour)},
+			{ID: "other-key", CreatedBy: "2", CreatedAt: time.Now().Add(-time.Hour)},
+		})
+		retired := recordRetired(t, mockClientService)
+
+		_, _, err := serviceAccountBuilder.Rotate(ctx, serviceAccountID, nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{"old-key"}, *retired)
+	})
+
+	t.Run("should keep the previous keys when the replaced key is ambiguous", func(t *testing.T) {
+		serviceAccountBuilder, mockClientService := newRotateBuilder(0, []*client.AccessKeyResponse{
+			{ID: "old-key", CreatedBy: "1", CreatedAt: time.Now().Add(-time.Hour)},
+			{ID: "pipeline-key", CreatedBy: "1", CreatedAt: time.Now().Add(-2 * time.Hour)},
+		})
+		retired := recordRetired(t, mockClientService)
+
+		_, _, err := serviceAccountBuilder.Rotate(ctx, serviceAccountID, nil)
+		require.NoError(t, err)
+		require.Empty(t, *retired)
+	})
+
+	t.Run("should keep the connector access key", func(t *testing.T) {
+		serviceAccountBuilder, mockClientService := newRotateBuilder(0, []*client.AccessKeyResponse{
+			{ID: "connector-key", CreatedBy: "1", CreatedAt: time.Now().Add(-time.Hour)},
+		})
+		retired := recordRetired(t, mockClientService)
+
+		_, _, err := serviceAccountBuilder.Rotate(ctx, serviceAccountID, nil)
+		require.NoError(t, err)
+		require.Empty(t, *retired)
+	})
+
+	t.Run("should keep the previous key with an overlap", func(t *testing.T) {
+		serviceAccountBuilder, mockClientService := newRotateBuilder(24*time.Hour, []*client.AccessKeyResponse{
+			{ID: "old-key", CreatedBy: "1", CreatedAt: time.Now().Add(-48 * time.Hour)},
+		})
+		retired := recordRetired(t, mockClientService)
+
+		_, _, err := serviceAccountBuilder.Rotate(ctx, serviceAccountID, nil)
+		require.NoError(t, err)
+		require.Empty(t, *retired)
+	})
+
+	t.Run("should refuse credentials other than random", func(t *testing.T) {
+		serviceAccountBuilder, _ := newTestServiceAccountBuilder()
+
+		_, _, err := serviceAccountBuilder.Rotate(ctx, serviceAccountID, &v2.CredentialOptions{
+			Options: &v2.CredentialOptions_NoPassword_{NoPassword: &v2.CredentialOptions_NoPassword{}},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
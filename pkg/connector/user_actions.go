@@ -0,0 +1,494 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	v1 "github.com/conductorone/baton-sdk/pb/c1/config/v1"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	updateUserAction    = "update_user"
+	disableUserAction   = "disable_user"
+	enableUserAction    = "enable_user"
+	unlockUserAction    = "unlock_user"
+	resetPasswordAction = "reset_user_password"
+	resetMfaAction      = "reset_user_mfa"
+	deleteUserAction    = "delete_user"
+	changeEmailAction   = "change_user_email"
+
+	userIDArgument     = "user_id"
+	firstNameArgument  = "first_name"
+	lastNameArgument   = "last_name"
+	rolesArgument      = "roles"
+	emailArgument      = "email"
+	passwordArgument   = "password"
+	transferToArgument = "transfer_to"
+	newEmailArgument   = "new_email"
+
+	// The email change states reported by the change email action.
+	emailChangePending   = "pending"
+	emailChangeConfirmed = "confirmed"
+)
+
+func (m *actionManager) registerUserActions() {
+	userID := stringActionField(userIDArgument, "User ID", "The ID of the Sumo Logic user.", true)
+
+	m.register(&v2.BatonActionSchema{
+		Name:        updateUserAction,
+		DisplayName: "Update User",
+		Description: "Update the name of a Sumo Logic user, and replace their roles with the given set in a single call.",
+		Arguments: []*v1.Field{
+			userID,
+			stringActionField(firstNameArgument, "First Name", "The new first name, unchanged if omitted.", false),
+			stringActionField(lastNameArgument, "Last Name", "The new last name, unchanged if omitted.", false),
+			stringListActionField(rolesArgument, "Roles", "IDs or names of every role the user should hold, unchanged if omitted.", false),
+		},
+		ReturnTypes: []*v1.Field{
+			boolActionField("success", "Success", "Whether the user was updated."),
+			stringActionField(firstNameArgument, "First Name", "The first name of the user.", false),
+			stringActionField(lastNameArgument, "Last Name", "The last name of the user.", false),
+			stringListActionField("role_ids", "Role IDs", "The IDs of the roles the user holds.", false),
+		},
+	}, m.updateUser)
+
+	activeReturnTypes := []*v1.Field{
+		boolActionField("success", "Success", "Whether the user was updated."),
+		boolActionField("is_active", "Active", "Whether the user is now active."),
+	}
+
+	m.register(&v2.BatonActionSchema{
+		Name:        disableUserAction,
+		DisplayName: "Disable User",
+		Description: "Disable a Sumo Logic user, they can no longer sign in but their content is kept.",
+		Arguments:   []*v1.Field{userID},
+		ReturnTypes: activeReturnTypes,
+	}, func(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+		return m.setUserActive(ctx, args, false)
+	})
+
+	m.register(&v2.BatonActionSchema{
+		Name:        enableUserAction,
+		DisplayName: "Enable User",
+		Description: "Enable a disabled Sumo Logic user.",
+		Arguments:   []*v1.Field{userID},
+		ReturnTypes: activeReturnTypes,
+	}, func(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+		return m.setUserActive(ctx, args, true)
+	})
+
+	m.register(&v2.BatonActionSchema{
+		Name:        unlockUserAction,
+		DisplayName: "Unlock User",
+		Description: "Unlock a Sumo Logic user locked out after too many failed sign in attempts.",
+		Arguments:   []*v1.Field{userID},
+		ReturnTypes: []*v1.Field{
+			boolActionField("success", "Success", "Whether the user was unlocked."),
+			boolActionField("is_locked", "Locked", "Whether the user is still locked."),
+		},
+	}, m.unlockUser)
+
+	m.register(&v2.BatonActionSchema{
+		Name:        resetPasswordAction,
+		DisplayName: "Reset User Password",
+		Description: "Reset the password of a Sumo Logic user, they receive an email to choose a new one.",
+		Arguments:   []*v1.Field{userID},
+		ReturnTypes: []*v1.Field{
+			boolActionField("success", "Success", "Whether the password reset email was sent."),
+		},
+	}, m.resetPassword)
+
+	m.register(&v2.BatonActionSchema{
+		Name:        resetMfaAction,
+		DisplayName: "Reset User MFA",
+		Description: "Disable the multi-factor authentication of a Sumo Logic user so they can enroll a new device. " +
+			"Sumo Logic requires the email and password of the user to confirm it.",
+		Arguments: []*v1.Field{
+			userID,
+			stringActionField(emailArgument, "Email", "The email of the user, defaults to their current email.", false),
+			{
+				Name:        passwordArgument,
+				DisplayName: "Password",
+				Description: "The password of the user, required by Sumo Logic to disable their MFA.",
+				IsRequired:  true,
+				IsSecret:    true,
+				Field:       &v1.Field_StringField{StringField: &v1.StringField{}},
+			},
+		},
+		ReturnTypes: []*v1.Field{
+			boolActionField("success", "Success", "Whether MFA was disabled."),
+			boolActionField("mfa_enabled", "MFA Enabled", "Whether MFA is still enabled for the user."),
+		},
+	}, m.resetMfa)
+
+	m.register(&v2.BatonActionSchema{
+		Name:        deleteUserAction,
+		DisplayName: "Delete User",
+		Description: "Delete a Sumo Logic user and transfer their searches, dashboards and monitors to a successor.",
+		Arguments: []*v1.Field{
+			userID,
+			stringActionField(
+				transferToArgument,
+				"Transfer To",
+				"The ID or email of the active user who receives the content, defaults to the configured successor.",
+				false,
+			),
+		},
+		ReturnTypes: []*v1.Field{
+			boolActionField("success", "Success", "Whether the user was deleted."),
+		},
+	}, m.deleteUser)
+
+	m.register(&v2.BatonActionSchema{
+		Name:        changeEmailAction,
+		DisplayName: "Change User Email",
+		Description: "Change the email a Sumo Logic user signs in with. " +
+			"Sumo Logic sends a confirmation to the new address, and keeps the current one until it is confirmed.",
+		Arguments: []*v1.Field{
+			userID,
+			stringActionField(newEmailArgument, "New Email", "The new email address of the user.", true),
+		},
+		ReturnTypes: []*v1.Field{
+			boolActionField("success", "Success", "Whether the email change was requested."),
+			stringActionField(emailArgument, "Email", "The confirmed email of the user.", false),
+			stringActionField(newEmailArgument, "New Email", "The requested email of the user.", false),
+			stringActionField("status", "Status", "pending until the user confirms the new address, then confirmed.", false),
+		},
+	}, m.changeEmail)
+}
+
+// updateUser applies the given attributes on top of the current ones, since the update endpoint replaces the whole user.
+func (m *actionManager) updateUser(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	userID, err := stringArgument(args, userIDArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+	firstName, hasFirstName, err := optionalStringArgument(args, firstNameArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+	lastName, hasLastName, err := optionalStringArgument(args, lastNameArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+	roles, hasRoles, err := stringListArgument(args, rolesArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hasFirstName && !hasLastName && !hasRoles {
+		return nil, nil, status.Errorf(
+			codes.InvalidArgument,
+			"baton-sumo-logic: one of %s, %s or %s is required",
+			firstNameArgument,
+			lastNameArgument,
+			rolesArgument,
+		)
+	}
+	if hasRoles && len(roles) == 0 {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: a user must hold at least one role")
+	}
+
+	// The update resends the fields that are not changed, so they are read without the cache.
+	outputAnnotations := annotations.New()
+	user, rateLimit, err := m.service.GetUserByID(client.WithoutCache(ctx), userID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get user: %w", err)
+	}
+
+	userRequest := userToUpdateRequest(user)
+	if hasFirstName {
+		userRequest.FirstName = firstName
+	}
+	if hasLastName {
+		userRequest.LastName = lastName
+	}
+	if hasRoles {
+		roleIDs, roleAnnotations, err := resolveRoleIDs(ctx, m.service, roles)
+		outputAnnotations = append(outputAnnotations, roleAnnotations...)
+		if err != nil {
+			return nil, outputAnnotations, err
+		}
+		userRequest.RoleIDs = roleIDs
+	}
+
+	updated, rateLimit, err := m.service.UpdateUser(ctx, user.ID, userRequest)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to update user: %w", err)
+	}
+
+	response, err := actionResponse(map[string]interface{}{
+		userIDArgument:    updated.ID,
+		firstNameArgument: updated.FirstName,
+		lastNameArgument:  updated.LastName,
+		"role_ids":        stringsToValues(updated.RoleIDs),
+	})
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	return response, outputAnnotations, nil
+}
+
+func (m *actionManager) setUserActive(ctx context.Context, args *structpb.Struct, active bool) (*structpb.Struct, annotations.Annotations, error) {
+	userID, err := stringArgument(args, userIDArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, outputAnnotations, err := setUserActive(ctx, m.service, m.apiAccessID, userID, active)
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	response, err := actionResponse(map[string]interface{}{
+		userIDArgument: user.ID,
+		"is_active":    user.IsActive != nil && *user.IsActive,
+	})
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	return response, outputAnnotations, nil
+}
+
+func (m *actionManager) unlockUser(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	userID, err := stringArgument(args, userIDArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outputAnnotations := annotations.New()
+	rateLimit, err := m.service.UnlockUser(ctx, userID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to unlock user: %w", err)
+	}
+
+	response, err := actionResponse(map[string]interface{}{
+		userIDArgument: userID,
+		"is_locked":    false,
+	})
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	return response, outputAnnotations, nil
+}
+
+func (m *actionManager) resetPassword(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	userID, err := stringArgument(args, userIDArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outputAnnotations := annotations.New()
+	rateLimit, err := m.service.ResetPassword(ctx, userID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to reset user password: %w", err)
+	}
+
+	response, err := actionResponse(map[string]interface{}{
+		userIDArgument: userID,
+	})
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	return response, outputAnnotations, nil
+}
+
+// resetMfa disables the MFA of a user and reads the user back, so the MFA status of the user resource is up to date.
+func (m *actionManager) resetMfa(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	userID, err := stringArgument(args, userIDArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+	email, hasEmail, err := optionalStringArgument(args, emailArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, ok := args.GetFields()[passwordArgument]; !ok {
+		return nil, nil, status.Errorf(
+			codes.InvalidArgument,
+			"baton-sumo-logic: Sumo Logic requires the password of the user to disable their MFA, set the %s argument",
+			passwordArgument,
+		)
+	}
+	// The password is used as given, it may start or end with spaces.
+	password := args.GetFields()[passwordArgument].GetStringValue()
+	if password == "" {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: argument %s must be a non-empty string", passwordArgument)
+	}
+
+	// The email confirming the reset must be the current one, not the one cached by the last sync.
+	outputAnnotations := annotations.New()
+	user, rateLimit, err := m.service.GetUserByID(client.WithoutCache(ctx), userID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get user: %w", err)
+	}
+	if !hasEmail {
+		email = user.Email
+	}
+
+	rateLimit, err = m.service.DisableMfa(ctx, user.ID, email, password)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument, codes.Unauthenticated:
+		// The connector credentials were accepted by the previous call, so the user confirmation was rejected.
+		return nil, outputAnnotations, status.Errorf(
+			codes.InvalidArgument,
+			"baton-sumo-logic: Sumo Logic rejected the email and password confirming the MFA reset of user %s: %v",
+			user.ID,
+			err,
+		)
+	case codes.PermissionDenied:
+		return nil, outputAnnotations, fmt.Errorf(
+			"baton-sumo-logic: failed to disable user MFA, the connector access key needs the %s capability: %w",
+			capabilityManageUsersAndRoles,
+			err,
+		)
+	default:
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to disable user MFA: %w", err)
+	}
+
+	mfaEnabled := false
+	updated, rateLimit, err := m.service.GetUserByID(client.WithoutCache(ctx), user.ID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		ctxzap.Extract(ctx).Warn("baton-sumo-logic: reset-mfa: failed to read the user back", zap.String("userID", user.ID), zap.Error(err))
+	} else if updated.IsMfaEnabled != nil {
+		mfaEnabled = *updated.IsMfaEnabled
+	}
+
+	response, err := actionResponse(map[string]interface{}{
+		userIDArgument: user.ID,
+		"mfa_enabled":  mfaEnabled,
+	})
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	return response, outputAnnotations, nil
+}
+
+// deleteUser always deletes the user, even when deprovisioning only disables users.
+func (m *actionManager) deleteUser(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	userID, err := stringArgument(args, userIDArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+	transferTo, ok, err := optionalStringArgument(args, transferToArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		transferTo = m.userContentSuccessor
+	}
+
+	outputAnnotations, err := deleteUser(ctx, m.service, userID, transferTo)
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	response, err := actionResponse(map[string]interface{}{
+		userIDArgument:     userID,
+		transferToArgument: transferTo,
+	})
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	return response, outputAnnotations, nil
+}
+
+// changeEmail requests the email change and reads the user back, the email of the user is only
+// updated once they confirm the new address, so the change is reported as pending until then.
+func (m *actionManager) changeEmail(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	userID, err := stringArgument(args, userIDArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+	newEmail, err := stringArgument(args, newEmailArgument)
+	if err != nil {
+		return nil, nil, err
+	}
+	if address, err := mail.ParseAddress(newEmail); err != nil || address.Address != newEmail {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: %s %q is not a valid email address", newEmailArgument, newEmail)
+	}
+
+	outputAnnotations := annotations.New()
+	user, rateLimit, err := m.service.GetUserByID(client.WithoutCache(ctx), userID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get user: %w", err)
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		return nil, outputAnnotations, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: user %s already has the email %s", user.ID, newEmail)
+	}
+
+	rateLimit, err = m.service.RequestChangeEmail(ctx, user.ID, newEmail)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to request the email change: %w", err)
+	}
+
+	email := user.Email
+	updated, rateLimit, err := m.service.GetUserByID(client.WithoutCache(ctx), user.ID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		ctxzap.Extract(ctx).Warn("baton-sumo-logic: change-email: failed to read the user back", zap.String("userID", user.ID), zap.Error(err))
+	} else {
+		email = updated.Email
+	}
+
+	changeStatus := emailChangePending
+	if strings.EqualFold(email, newEmail) {
+		changeStatus = emailChangeConfirmed
+	}
+
+	response, err := actionResponse(map[string]interface{}{
+		userIDArgument:   user.ID,
+		emailArgument:    email,
+		newEmailArgument: newEmail,
+		"status":         changeStatus,
+	})
+	if err != nil {
+		return nil, outputAnnotations, err
+	}
+
+	return response, outputAnnotations, nil
+}
+
+// userToUpdateRequest returns an update request that keeps every attribute of the user unchanged.
+func userToUpdateRequest(user *client.UserResponse) client.UserUpdateRequest {
+	return client.UserUpdateRequest{
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		IsActive:  user.IsActive != nil && *user.IsActive,
+		RoleIDs:   user.RoleIDs,
+	}
+}
+
+// stringsToValues converts a string slice into a list accepted by structpb.
+func stringsToValues(values []string) []interface{} {
+	rv := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		rv = append(rv, value)
+	}
+	return rv
+}
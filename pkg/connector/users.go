@@ -3,6 +3,7 @@ package connector
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
@@ -18,8 +19,12 @@ import (
 )
 
 type userBuilder struct {
-	service                client.ClientService
-	includeServiceAccounts bool
+	service     client.ClientService
+	apiAccessID string
+	// disableOnDeprovision makes Delete disable users instead of deleting them.
+	disableOnDeprovision bool
+	// contentSuccessor is the ID or email of the user who receives the content of deleted users.
+	contentSuccessor string
 }
 
 func (o *userBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
@@ -50,7 +55,13 @@ func (o *userBuilder) CreateAccount(
 		return nil, nil, nil, err
 	}
 
-	outputAnnotations := annotations.New()
+	// Every role is checked before the user is created, so a typo does not leave a user with partial access.
+	roleIDs, outputAnnotations, err := resolveRoleIDs(ctx, o.service, userRequest.RoleIDs)
+	if err != nil {
+		return nil, nil, outputAnnotations, err
+	}
+	userRequest.RoleIDs = roleIDs
+
 	user, rateLimit, err := o.service.CreateUser(ctx, *userRequest)
 	outputAnnotations.WithRateLimiting(rateLimit)
 	if err != nil {
@@ -59,17 +70,19 @@ func (o *userBuilder) CreateAccount(
 
 	userResource, err := createUserResource(user)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, outputAnnotations, err
 	}
 
 	car := &v2.CreateAccountResponse_SuccessResult{
 		Resource: userResource,
 	}
 
-	return car, nil, nil, nil
+	return car, nil, outputAnnotations, nil
 }
 
 // Delete implements the ResourceDeleter interface.
+// When deprovisioning disables users, the user is disabled and kept in Sumo Logic.
+// Otherwise the content of the user is transferred to the configured successor, if any.
 func (o *userBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
 	accountID := resourceId.GetResource()
 	if len(accountID) == 0 {
@@ -77,27 +90,81 @@ func (o *userBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (an
 	}
 	l := ctxzap.Extract(ctx).With(zap.String("accountID", accountID))
 
+	if o.disableOnDeprovision {
+		_, outputAnnotations, err := setUserActive(ctx, o.service, o.apiAccessID, accountID, false)
+		if status.Code(err) == codes.NotFound {
+			l.Info("baton-sumo-logic: disable-user: account was already deleted")
+			return outputAnnotations, nil
+		}
+		if err != nil {
+			l.Error("baton-sumo-logic: disable-user: failed to disable account", zap.Error(err))
+			return outputAnnotations, err
+		}
+
+		l.Info("baton-sumo-logic: disable-user: success")
+		return outputAnnotations, nil
+	}
+
+	return deleteUser(ctx, o.service, accountID, o.contentSuccessor)
+}
+
+// deleteUser deletes a user and checks it is gone. The content of the user is transferred to the successor,
+// given as a user ID or email, which must be an active user other than the deleted one.
+func deleteUser(ctx context.Context, service client.ClientService, accountID string, successor string) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx).With(zap.String("accountID", accountID))
+
 	// check the account exists
 	outputAnnotations := annotations.New()
-	account, rateLimit, err := o.service.GetUserByID(ctx, accountID)
+	account, rateLimit, err := service.GetUserByID(ctx, accountID)
 	outputAnnotations.WithRateLimiting(rateLimit)
+	if status.Code(err) == codes.NotFound {
+		l.Info("baton-sumo-logic: delete-user: account was already deleted")
+		return outputAnnotations, nil
+	}
 	if err != nil {
 		l.Error("baton-sumo-logic: delete-user: failed to get account by user ID", zap.Error(err))
 		return outputAnnotations, err
 	}
 
+	var transferTo string
+	if successor != "" {
+		successorAccount, successorAnnotations, err := resolveSuccessor(ctx, service, successor)
+		outputAnnotations = append(outputAnnotations, successorAnnotations...)
+		if err != nil {
+			l.Error("baton-sumo-logic: delete-user: invalid content successor", zap.Error(err))
+			return outputAnnotations, err
+		}
+		if successorAccount.ID == account.ID {
+			return outputAnnotations, status.Errorf(
+				codes.FailedPrecondition,
+				"baton-sumo-logic: cannot transfer the content of user %s to themselves",
+				account.ID,
+			)
+		}
+		transferTo = successorAccount.ID
+		l = l.With(zap.String("transferTo", transferTo))
+	}
+
 	// delete the account
-	rateLimit, err = o.service.DeleteUser(ctx, account.ID)
+	rateLimit, err = service.DeleteUser(ctx, account.ID, transferTo)
 	outputAnnotations.WithRateLimiting(rateLimit)
+	if status.Code(err) == codes.NotFound {
+		l.Info("baton-sumo-logic: delete-user: account was already deleted")
+		return outputAnnotations, nil
+	}
 	if err != nil {
 		l.Error("baton-sumo-logic: delete-user: failed to delete account with user ID", zap.Error(err))
 		return outputAnnotations, err
 	}
 
-	// verify the account no longer exists
-	_, rateLimit, err = o.service.GetUserByID(ctx, account.ID)
+	// verify the account no longer exists, the cached response from the first check would still show it
+	_, rateLimit, err = service.GetUserByID(client.WithoutCache(ctx), account.ID)
 	outputAnnotations.WithRateLimiting(rateLimit)
-	if err == nil || status.Code(err) != codes.NotFound {
+	if err == nil {
+		l.Error("baton-sumo-logic: delete-user: failed: Account with ID should have been deleted")
+		return outputAnnotations, fmt.Errorf("baton-sumo-logic: account %s still exists after deletion", account.ID)
+	}
+	if status.Code(err) != codes.NotFound {
 		l.Error("baton-sumo-logic: delete-user: failed: Account with ID should have been deleted", zap.Error(err))
 		return outputAnnotations, err
 	}
@@ -106,38 +173,74 @@ func (o *userBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (an
 	return nil, nil
 }
 
-// List returns all accounts (human and service accounts) from Sumo Logic as resource objects.
-func (o *userBuilder) List(ctx context.Context, _ *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+// resolveSuccessor returns the user identified by the ID or email, refusing inactive users,
+// since Sumo Logic cannot transfer content to them.
+func resolveSuccessor(ctx context.Context, service client.ClientService, successor string) (*client.UserResponse, annotations.Annotations, error) {
 	outputAnnotations := annotations.New()
-	resources := make([]*v2.Resource, 0)
 
-	// Service accounts endpoint does not support pagination, so we only fetch them on the first page.
-	if pToken.Token == "" && o.includeServiceAccounts {
-		// Fetch both human and service accounts
-		serviceAccounts, rateLimit, err := o.service.GetServiceAccounts(ctx)
+	var user *client.UserResponse
+	if strings.Contains(successor, "@") {
+		var pageToken *string
+		for user == nil {
+			users, nextPageToken, rateLimit, err := service.GetUsers(ctx, pageToken)
+			outputAnnotations.WithRateLimiting(rateLimit)
+			if err != nil {
+				return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to list users: %w", err)
+			}
+
+			for _, u := range users {
+				if strings.EqualFold(u.Email, successor) {
+					user = u
+					break
+				}
+			}
+
+			if nextPageToken == nil || *nextPageToken == "" {
+				break
+			}
+			pageToken = nextPageToken
+		}
+		if user == nil {
+			return nil, outputAnnotations, status.Errorf(codes.FailedPrecondition, "baton-sumo-logic: content successor %s not found", successor)
+		}
+	} else {
+		var (
+			rateLimit *v2.RateLimitDescription
+			err       error
+		)
+		user, rateLimit, err = service.GetUserByID(ctx, successor)
 		outputAnnotations.WithRateLimiting(rateLimit)
+		if status.Code(err) == codes.NotFound {
+			return nil, outputAnnotations, status.Errorf(codes.FailedPrecondition, "baton-sumo-logic: content successor %s not found", successor)
+		}
 		if err != nil {
-			return nil, "", outputAnnotations, fmt.Errorf("failed to get service accounts: %w", err)
+			return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get content successor: %w", err)
 		}
+	}
 
-		// Process service accounts
-		for _, serviceAccount := range serviceAccounts {
-			userResource, err := createUserResource(serviceAccount)
-			if err != nil {
-				return nil, "", outputAnnotations, fmt.Errorf("failed to create user resource from service account: %w", err)
-			}
-			resources = append(resources, userResource)
-		}
+	if user.IsActive == nil || !*user.IsActive {
+		return nil, outputAnnotations, status.Errorf(
+			codes.FailedPrecondition,
+			"baton-sumo-logic: content successor %s is inactive, Sumo Logic cannot transfer content to them",
+			successor,
+		)
 	}
 
-	// Fetch and process human accounts
+	return user, outputAnnotations, nil
+}
+
+// List returns all human accounts from Sumo Logic as resource objects.
+// Service accounts are synced by the service account builder.
+func (o *userBuilder) List(ctx context.Context, _ *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
 	humanAccounts, nextPageToken, rateLimit, err := o.service.GetUsers(ctx, parsePageToken(pToken))
 	outputAnnotations.WithRateLimiting(rateLimit)
 	if err != nil {
 		return nil, "", outputAnnotations, fmt.Errorf("failed to get human accounts: %w", err)
 	}
 
-	// Process human accounts
+	resources := make([]*v2.Resource, 0, len(humanAccounts))
 	for _, humanAccount := range humanAccounts {
 		userResource, err := createUserResource(humanAccount)
 		if err != nil {
@@ -149,6 +252,25 @@ func (o *userBuilder) List(ctx context.Context, _ *v2.ResourceId, pToken *pagina
 	return resources, createPageToken(nextPageToken), outputAnnotations, nil
 }
 
+// Get implements the ResourceTargetedSyncer interface.
+// Targeted syncs usually follow a change to the user, so it is read without the cache.
+func (o *userBuilder) Get(ctx context.Context, resourceId *v2.ResourceId, _ *v2.ResourceId) (*v2.Resource, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	user, rateLimit, err := o.service.GetUserByID(client.WithoutCache(ctx), resourceId.Resource)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	userResource, err := createUserResource(user)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("failed to create user resource from human account: %w", err)
+	}
+
+	return userResource, outputAnnotations, nil
+}
+
 // Entitlements always returns an empty slice for users.
 func (o *userBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
 	return nil, "", nil, nil
@@ -159,17 +281,73 @@ func (o *userBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken
 	return nil, "", nil, nil
 }
 
-func newUserBuilder(cclient *client.Client, includeServiceAccounts bool) *userBuilder {
+func newUserBuilder(
+	cclient *client.Client,
+	apiAccessID string,
+	disableOnDeprovision bool,
+	contentSuccessor string,
+) *userBuilder {
 	return &userBuilder{
-		service:                client.NewClientService(cclient),
-		includeServiceAccounts: includeServiceAccounts,
+		service:              client.NewClientService(cclient),
+		apiAccessID:          apiAccessID,
+		disableOnDeprovision: disableOnDeprovision,
+		contentSuccessor:     contentSuccessor,
 	}
 }
 
+// setUserActive enables or disables a user, doing nothing if the user is already in that state.
+// The owner of the connector access key is never disabled, since the connector would lose access.
+func setUserActive(
+	ctx context.Context,
+	service client.ClientService,
+	apiAccessID string,
+	userID string,
+	active bool,
+) (*client.UserResponse, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	// The update resends the whole user, so it is read without the cache to keep the current state and roles.
+	user, rateLimit, err := service.GetUserByID(client.WithoutCache(ctx), userID)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to get user: %w", err)
+	}
+
+	if user.IsActive != nil && *user.IsActive == active {
+		return user, outputAnnotations, nil
+	}
+
+	if !active {
+		owner, err := accessKeyOwner(ctx, service, apiAccessID)
+		if err != nil {
+			return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to resolve the owner of the connector access key: %w", err)
+		}
+		if owner == user.ID {
+			return nil, outputAnnotations, status.Errorf(
+				codes.FailedPrecondition,
+				"baton-sumo-logic: refusing to disable user %s, they own the access key the connector is authenticated with",
+				user.ID,
+			)
+		}
+	}
+
+	userRequest := userToUpdateRequest(user)
+	userRequest.IsActive = active
+	updated, rateLimit, err := service.UpdateUser(ctx, user.ID, userRequest)
+	outputAnnotations.WithRateLimiting(rateLimit)
+	if err != nil {
+		return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to update user: %w", err)
+	}
+
+	return updated, outputAnnotations, nil
+}
+
 // createUserResource creates a resource object for either a UserResponse or ServiceAccountResponse.
+// Service accounts get the service account resource type.
 func createUserResource(account interface{}) (*v2.Resource, error) {
 	var fullName string
 	var base client.BaseAccount
+	resourceType := userResourceType
 	switch a := account.(type) {
 	case *client.UserResponse:
 		base = a.BaseAccount
@@ -186,9 +364,11 @@ func createUserResource(account interface{}) (*v2.Resource, error) {
 		"created_by":  base.CreatedBy,
 		"modified_at": base.ModifiedAt.Format(time.RFC3339),
 		"modified_by": base.ModifiedBy,
+		"role_ids":    strings.Join(base.RoleIDs, ","),
 	}
 
 	// Initialize base user trait options with common fields (email, login, and creation time).
+	// The email is the confirmed one, Sumo Logic does not expose requested email changes until they are confirmed.
 	userTraitOptions := []rs.UserTraitOption{
 		rs.WithUserLogin(base.Email),
 		rs.WithEmail(base.Email, true),
@@ -229,6 +409,8 @@ func createUserResource(account interface{}) (*v2.Resource, error) {
 	case *client.ServiceAccountResponse:
 		fullName = a.Name
 		profile["full_name"] = fullName
+		profile["name"] = a.Name
+		resourceType = serviceAccountResourceType
 
 		userTraitOptions = append(userTraitOptions, rs.WithAccountType(v2.UserTrait_ACCOUNT_TYPE_SERVICE))
 
@@ -244,39 +426,121 @@ func createUserResource(account interface{}) (*v2.Resource, error) {
 	// Create the resource
 	return rs.NewUserResource(
 		fullName,
-		userResourceType,
+		resourceType,
 		base.ID,
 		userTraitOptions,
 	)
 }
 
+// accountInfoToUserRequest builds a create user request from the account info.
+// The roles are given as IDs or names in "roles", or as a single ID in the legacy "default_role_id",
+// and must be resolved to role IDs before the user is created.
 func accountInfoToUserRequest(accountInfo *v2.AccountInfo) (*client.UserRequest, error) {
-	pMap := accountInfo.Profile.AsMap()
+	pMap := accountInfo.GetProfile().AsMap()
 
-	firstName, ok := pMap["first_name"]
-	if !ok {
-		return nil, fmt.Errorf("missing first name in account info")
+	firstName, err := accountInfoString(pMap, "first_name")
+	if err != nil {
+		return nil, err
 	}
 
-	lastName, ok := pMap["last_name"]
-	if !ok {
-		return nil, fmt.Errorf("missing last name in account info")
+	lastName, err := accountInfoString(pMap, "last_name")
+	if err != nil {
+		return nil, err
 	}
 
-	email, ok := pMap["email"]
-	if !ok {
-		return nil, fmt.Errorf("missing email in account info")
+	email, err := accountInfoString(pMap, "email")
+	if err != nil {
+		return nil, err
 	}
 
-	roleID, ok := pMap["default_role_id"]
-	if !ok {
-		return nil, fmt.Errorf("missing default role ID in account info")
+	roles, err := profileStringList(pMap, "roles")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: %s", err)
+	}
+	if _, ok := pMap["default_role_id"]; ok {
+		defaultRoleID, err := accountInfoString(pMap, "default_role_id")
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, defaultRoleID)
+	}
+	if len(roles) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: missing roles in account info")
 	}
 
 	return &client.UserRequest{
-		FirstName: firstName.(string),
-		LastName:  lastName.(string),
-		Email:     email.(string),
-		RoleIDs:   []string{roleID.(string)},
+		FirstName: firstName,
+		LastName:  lastName,
+		Email:     email,
+		RoleIDs:   roles,
 	}, nil
 }
+
+// accountInfoString returns a required, non-empty string value of the account info profile.
+func accountInfoString(pMap map[string]interface{}, key string) (string, error) {
+	value, ok := pMap[key]
+	if !ok {
+		return "", status.Errorf(codes.InvalidArgument, "baton-sumo-logic: missing %s in account info", key)
+	}
+
+	s, ok := value.(string)
+	if !ok || strings.TrimSpace(s) == "" {
+		return "", status.Errorf(codes.InvalidArgument, "baton-sumo-logic: %s in account info must be a non-empty string", key)
+	}
+
+	return strings.TrimSpace(s), nil
+}
+
+// resolveRoleIDs turns role IDs or names into role IDs, failing if any role does not exist.
+// Names are matched case-insensitively, an exact ID match takes precedence.
+func resolveRoleIDs(ctx context.Context, service client.ClientService, roles []string) ([]string, annotations.Annotations, error) {
+	outputAnnotations := annotations.New()
+
+	roleIDs := make(map[string]struct{})
+	roleNames := make(map[string]string)
+	var pageToken *string
+	for {
+		page, nextPageToken, rateLimit, err := service.GetRoles(ctx, pageToken)
+		outputAnnotations.WithRateLimiting(rateLimit)
+		if err != nil {
+			return nil, outputAnnotations, fmt.Errorf("baton-sumo-logic: failed to list roles: %w", err)
+		}
+
+		for _, role := range page {
+			roleIDs[role.ID] = struct{}{}
+			roleNames[strings.ToLower(role.Name)] = role.ID
+		}
+
+		if nextPageToken == nil || *nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
+	}
+
+	var (
+		rv      []string
+		unknown []string
+	)
+	seen := make(map[string]struct{})
+	for _, role := range roles {
+		roleID := role
+		if _, ok := roleIDs[role]; !ok {
+			if roleID, ok = roleNames[strings.ToLower(role)]; !ok {
+				unknown = append(unknown, role)
+				continue
+			}
+		}
+
+		if _, ok := seen[roleID]; ok {
+			continue
+		}
+		seen[roleID] = struct{}{}
+		rv = append(rv, roleID)
+	}
+
+	if len(unknown) > 0 {
+		return nil, outputAnnotations, status.Errorf(codes.InvalidArgument, "baton-sumo-logic: unknown roles: %s", strings.Join(unknown, ", "))
+	}
+
+	return rv, outputAnnotations, nil
+}
@@ -11,15 +11,18 @@ import (
 	test "github.com/conductorone/baton-sdk/pkg/test"
 	"github.com/conductorone/baton-sumo-logic/pkg/client"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Helper function to create a test builder with mocks.
-func newTestUserBuilder(includeServiceAccounts bool) (*userBuilder, *client.MockClientService) {
+func newTestUserBuilder() (*userBuilder, *client.MockClientService) {
 	mockClient := &client.Client{}
 	mockClientService := &client.MockClientService{}
 
-	builder := newUserBuilder(mockClient, includeServiceAccounts)
+	builder := newUserBuilder(mockClient, "connector-key", false, "")
 	// Replace the service with our mock.
 	builder.service = mockClientService
 
@@ -29,9 +32,9 @@ func newTestUserBuilder(includeServiceAccounts bool) (*userBuilder, *client.Mock
 func TestUsersList(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("should get ratelimit annotations from users (without service accounts)", func(t *testing.T) {
+	t.Run("should get ratelimit annotations from users", func(t *testing.T) {
 		// Create a new user builder with a mock client service.
-		userBuilder, mockClientService := newTestUserBuilder(false)
+		userBuilder, mockClientService := newTestUserBuilder()
 
 		mockClientService.GetUsersFunc = func(
 			ctx context.Context,
@@ -65,43 +68,9 @@ func TestUsersList(t *testing.T) {
 		require.NotNil(t, rateLimitData.ResetAt)
 	})
 
-	t.Run("should get ratelimit annotations from service accounts", func(t *testing.T) {
-		// Create a new user builder with a mock client service.
-		userBuilder, mockClientService := newTestUserBuilder(true)
-
-		mockClientService.GetServiceAccountsFunc = func(
-			ctx context.Context,
-		) (
-			[]*client.ServiceAccountResponse,
-			*v2.RateLimitDescription,
-			error,
-		) {
-			rateLimitData := v2.RateLimitDescription{
-				ResetAt: timestamppb.New(time.Now().Add(10 * time.Second)),
-			}
-			err := fmt.Errorf("ratelimit error")
-			return nil, &rateLimitData, err
-		}
-
-		resources, token, annotations, err := userBuilder.List(ctx, nil, &pagination.Token{})
-
-		require.Nil(t, resources)
-		require.Empty(t, token)
-		require.NotNil(t, err)
-
-		// There should be annotations.
-		require.Len(t, annotations, 1)
-		rateLimitData := v2.RateLimitDescription{}
-		err = annotations[0].UnmarshalTo(&rateLimitData)
-		if err != nil {
-			t.Errorf("couldn't unmarshal the ratelimit annotation")
-		}
-		require.NotNil(t, rateLimitData.ResetAt)
-	})
-
 	t.Run("should get passed a pagination token", func(t *testing.T) {
 		// Create a new user builder with a mock client service.
-		userBuilder, mockClientService := newTestUserBuilder(false)
+		userBuilder, mockClientService := newTestUserBuilder()
 
 		startToken := "start-token"
 		mockClientService.GetUsersFunc = func(
@@ -120,9 +89,9 @@ func TestUsersList(t *testing.T) {
 		_, _, _, _ = userBuilder.List(ctx, nil, &pagination.Token{Token: startToken})
 	})
 
-	t.Run("should get users without service accounts", func(t *testing.T) {
+	t.Run("should get users", func(t *testing.T) {
 		// Create a new user builder with a mock client service.
-		userBuilder, mockClientService := newTestUserBuilder(false)
+		userBuilder, mockClientService := newTestUserBuilder()
 
 		mockClientService.GetUsersFunc = func(
 			ctx context.Context,
@@ -173,74 +142,628 @@ func TestUsersList(t *testing.T) {
 		test.AssertNoRatelimitAnnotations(t, annotations)
 		require.Nil(t, err)
 	})
+}
 
-	t.Run("should get users with service accounts", func(t *testing.T) {
-		// Create a new user builder with a mock client service.
-		userBuilder, mockClientService := newTestUserBuilder(true)
+func TestUserDelete(t *testing.T) {
+	ctx := context.Background()
+	resourceID := &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "test-user"}
 
-		// Mock the service accounts.
-		mockClientService.GetServiceAccountsFunc = func(
-			ctx context.Context,
-		) (
-			[]*client.ServiceAccountResponse,
-			*v2.RateLimitDescription,
-			error,
-		) {
-			email := "baton-service-account@conductorone.com"
-			isActive := true
-			createdAt := time.Now()
-			modifiedAt := time.Now()
-			serviceAccounts := []*client.ServiceAccountResponse{
-				{
-					BaseAccount: client.BaseAccount{
-						ID:         "1",
-						Email:      email,
-						IsActive:   &isActive,
-						CreatedAt:  createdAt,
-						CreatedBy:  "test",
-						ModifiedBy: "test",
-						ModifiedAt: modifiedAt,
-					},
-					Name: "baton-service-account",
-				},
+	t.Run("should delete the user and confirm it is gone", func(t *testing.T) {
+		userBuilder, mockClientService := newTestUserBuilder()
+
+		deleted := false
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			if deleted {
+				return nil, nil, status.Error(codes.NotFound, "user:not_found")
 			}
-			return serviceAccounts, nil, nil
+			return &client.UserResponse{BaseAccount: client.BaseAccount{ID: userId}}, nil, nil
+		}
+		mockClientService.DeleteUserFunc = func(ctx context.Context, userId string, transferTo string) (*v2.RateLimitDescription, error) {
+			deleted = true
+			return nil, nil
 		}
 
-		// Mock the users.
-		mockClientService.GetUsersFunc = func(
+		_, err := userBuilder.Delete(ctx, resourceID)
+		require.NoError(t, err)
+		require.True(t, deleted)
+	})
+
+	t.Run("should fail when the user still exists after deletion", func(t *testing.T) {
+		userBuilder, mockClientService := newTestUserBuilder()
+
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return &client.UserResponse{BaseAccount: client.BaseAccount{ID: userId}}, nil, nil
+		}
+		mockClientService.DeleteUserFunc = func(ctx context.Context, userId string, transferTo string) (*v2.RateLimitDescription, error) {
+			return nil, nil
+		}
+
+		_, err := userBuilder.Delete(ctx, resourceID)
+		require.ErrorContains(t, err, "still exists")
+	})
+
+	t.Run("should succeed when the user was already deleted", func(t *testing.T) {
+		userBuilder, mockClientService := newTestUserBuilder()
+
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return nil, nil, status.Error(codes.NotFound, "user:not_found")
+		}
+
+		_, err := userBuilder.Delete(ctx, resourceID)
+		require.NoError(t, err)
+	})
+
+	t.Run("should succeed when the user is deleted before the delete request", func(t *testing.T) {
+		userBuilder, mockClientService := newTestUserBuilder()
+
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return &client.UserResponse{BaseAccount: client.BaseAccount{ID: userId}}, nil, nil
+		}
+		mockClientService.DeleteUserFunc = func(ctx context.Context, userId string, transferTo string) (*v2.RateLimitDescription, error) {
+			return nil, status.Error(codes.NotFound, "user:not_found")
+		}
+
+		_, err := userBuilder.Delete(ctx, resourceID)
+		require.NoError(t, err)
+	})
+}
+
+func TestUserGet(t *testing.T) {
+	ctx := context.Background()
+	resourceID := &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "test-id"}
+
+	t.Run("should get a user", func(t *testing.T) {
+		userBuilder, mockClientService := newTestUserBuilder()
+
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			require.Equal(t, "test-id", userId)
+			return &client.UserResponse{
+				BaseAccount: client.BaseAccount{ID: userId, Email: "jane@example.com"},
+				FirstName:   "Jane",
+				LastName:    "Doe",
+			}, nil, nil
+		}
+
+		resource, _, err := userBuilder.Get(ctx, resourceID, nil)
+		require.NoError(t, err)
+		require.Equal(t, "test-id", resource.Id.Resource)
+		require.Equal(t, userResourceType.Id, resource.Id.ResourceType)
+	})
+
+	t.Run("should return not found errors", func(t *testing.T) {
+		userBuilder, mockClientService := newTestUserBuilder()
+
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return nil, nil, status.Error(codes.NotFound, "user:not_found")
+		}
+
+		_, _, err := userBuilder.Get(ctx, resourceID, nil)
+		require.Error(t, err)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestUserCreateAccount(t *testing.T) {
+	ctx := context.Background()
+
+	newAccountInfo := func(t *testing.T, profile map[string]interface{}) *v2.AccountInfo {
+		p := map[string]interface{}{
+			"first_name": "Jane",
+			"last_name":  "Doe",
+			"email":      "jane.doe@example.com",
+		}
+		for k, v := range profile {
+			p[k] = v
+		}
+		pStruct, err := structpb.NewStruct(p)
+		require.NoError(t, err)
+		return &v2.AccountInfo{Profile: pStruct}
+	}
+
+	newCreateAccountBuilder := func() (*userBuilder, *client.MockClientService) {
+		userBuilder, mockClientService := newTestUserBuilder()
+		mockClientService.GetRolesFunc = func(ctx context.Context, pageToken *string) ([]*client.RoleResponse, *string, *v2.RateLimitDescription, error) {
+			return []*client.RoleResponse{
+				{ID: "role-1", Name: "Administrator"},
+				{ID: "role-2", Name: "Analyst"},
+			}, nil, nil, nil
+		}
+		return userBuilder, mockClientService
+	}
+
+	t.Run("should resolve role IDs and names", func(t *testing.T) {
+		userBuilder, mockClientService := newCreateAccountBuilder()
+		mockClientService.CreateUserFunc = func(ctx context.Context, userRequest client.UserRequest) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			require.Equal(t, []string{"role-1", "role-2"}, userRequest.RoleIDs)
+			isActive := true
+			return &client.UserResponse{
+				BaseAccount: client.BaseAccount{ID: "new-user", Email: userRequest.Email, RoleIDs: userRequest.RoleIDs, IsActive: &isActive},
+				FirstName:   userRequest.FirstName,
+				LastName:    userRequest.LastName,
+			}, nil, nil
+		}
+
+		response, _, _, err := userBuilder.CreateAccount(ctx, newAccountInfo(t, map[string]interface{}{
+			"roles": []interface{}{"role-1", "analyst", "Administrator"},
+		}), nil)
+		require.NoError(t, err)
+		result, ok := response.(*v2.CreateAccountResponse_SuccessResult)
+		require.True(t, ok)
+		require.Equal(t, "new-user", result.Resource.Id.Resource)
+	})
+
+	t.Run("should accept the legacy default role ID", func(t *testing.T) {
+		userBuilder, mockClientService := newCreateAccountBuilder()
+		mockClientService.CreateUserFunc = func(ctx context.Context, userRequest client.UserRequest) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			require.Equal(t, []string{"role-2"}, userRequest.RoleIDs)
+			return &client.UserResponse{BaseAccount: client.BaseAccount{ID: "new-user"}}, nil, nil
+		}
+
+		_, _, _, err := userBuilder.CreateAccount(ctx, newAccountInfo(t, map[string]interface{}{"default_role_id": "role-2"}), nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("should not create the user when a role does not exist", func(t *testing.T) {
+		userBuilder, mockClientService := newCreateAccountBuilder()
+		mockClientService.CreateUserFunc = func(ctx context.Context, userRequest client.UserRequest) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			t.Fatal("the user must not be created")
+			return nil, nil, nil
+		}
+
+		_, _, _, err := userBuilder.CreateAccount(ctx, newAccountInfo(t, map[string]interface{}{"roles": "Administrator, Auditor"}), nil)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.ErrorContains(t, err, "unknown roles: Auditor")
+	})
+
+	t.Run("should reject invalid account info", func(t *testing.T) {
+		userBuilder, _ := newCreateAccountBuilder()
+
+		for name, profile := range map[string]map[string]interface{}{
+			"missing roles":       {},
+			"non-string name":     {"first_name": 42.0, "roles": "role-1"},
+			"non-string role":     {"roles": []interface{}{"role-1", true}},
+			"non-string role ID":  {"default_role_id": 7.0},
+			"empty email address": {"email": " ", "roles": "role-1"},
+		} {
+			_, _, _, err := userBuilder.CreateAccount(ctx, newAccountInfo(t, profile), nil)
+			require.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+	})
+}
+
+func newTestActionUser(id string) *client.UserResponse {
+	isActive := true
+	return &client.UserResponse{
+		BaseAccount: client.BaseAccount{
+			ID:       id,
+			Email:    "jane.doe@example.com",
+			IsActive: &isActive,
+			RoleIDs:  []string{"role-1"},
+		},
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+}
+
+func TestUserUpdateAction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep the attributes that are not given", func(t *testing.T) {
+		manager, mockClientService := newTestActionManager()
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return newTestActionUser(userId), nil, nil
+		}
+		mockClientService.UpdateUserFunc = func(
 			ctx context.Context,
-			pageToken *string,
-		) (
-			[]*client.UserResponse,
-			*string,
-			*v2.RateLimitDescription,
-			error,
-		) {
-			email := "baton-user@conductorone.com"
-			users := []*client.UserResponse{
-				{
-					BaseAccount: client.BaseAccount{
-						ID:    "2",
-						Email: email,
-					},
-					FirstName: "Baton",
-					LastName:  "User",
-				},
+			userId string,
+			userRequest client.UserUpdateRequest,
+		) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			require.Equal(t, client.UserUpdateRequest{
+				FirstName: "Jane",
+				LastName:  "Smith",
+				IsActive:  true,
+				RoleIDs:   []string{"role-1"},
+			}, userRequest)
+
+			user := newTestActionUser(userId)
+			user.LastName = userRequest.LastName
+			return user, nil, nil
+		}
+
+		_, actionStatus, response, _, err := manager.InvokeAction(ctx, updateUserAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument:   "user-1",
+			lastNameArgument: "Smith",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, v2.BatonActionStatus_BATON_ACTION_STATUS_COMPLETE, actionStatus)
+		require.Equal(t, "Smith", response.GetFields()[lastNameArgument].GetStringValue())
+	})
+
+	t.Run("should replace the roles in a single update", func(t *testing.T) {
+		manager, mockClientService := newTestActionManager()
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return newTestActionUser(userId), nil, nil
+		}
+		mockClientService.GetRolesFunc = func(ctx context.Context, pageToken *string) ([]*client.RoleResponse, *string, *v2.RateLimitDescription, error) {
+			return []*client.RoleResponse{{ID: "role-2", Name: "Analyst"}, {ID: "role-3", Name: "Auditor"}}, nil, nil, nil
+		}
+		updates := 0
+		mockClientService.UpdateUserFunc = func(
+			ctx context.Context,
+			userId string,
+			userRequest client.UserUpdateRequest,
+		) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			updates++
+			require.Equal(t, []string{"role-2", "role-3"}, userRequest.RoleIDs)
+
+			user := newTestActionUser(userId)
+			user.RoleIDs = userRequest.RoleIDs
+			return user, nil, nil
+		}
+
+		_, _, response, _, err := manager.InvokeAction(ctx, updateUserAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument: "user-1",
+			rolesArgument:  []interface{}{"Analyst", "role-3"},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, 1, updates)
+		require.Len(t, response.GetFields()["role_ids"].GetListValue().GetValues(), 2)
+	})
+
+	t.Run("should require an attribute to update", func(t *testing.T) {
+		manager, _ := newTestActionManager()
+
+		_, _, _, _, err := manager.InvokeAction(ctx, updateUserAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument: "user-1",
+		}))
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("should refuse an empty role set", func(t *testing.T) {
+		manager, _ := newTestActionManager()
+
+		_, _, _, _, err := manager.InvokeAction(ctx, updateUserAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument: "user-1",
+			rolesArgument:  []interface{}{},
+		}))
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestUserActiveActions(t *testing.T) {
+	ctx := context.Background()
+
+	connectorKeys := func(ctx context.Context) ([]*client.AccessKeyResponse, *v2.RateLimitDescription, error) {
+		return []*client.AccessKeyResponse{{ID: "connector-key", CreatedBy: "admin-1"}}, nil, nil
+	}
+
+	t.Run("should disable the user", func(t *testing.T) {
+		manager, mockClientService := newTestActionManager()
+		mockClientService.GetPersonalAccessKeysFunc = connectorKeys
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return newTestActionUser(userId), nil, nil
+		}
+		mockClientService.UpdateUserFunc = func(
+			ctx context.Context,
+			userId string,
+			userRequest client.UserUpdateRequest,
+		) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			require.False(t, userRequest.IsActive)
+			require.Equal(t, []string{"role-1"}, userRequest.RoleIDs)
+
+			user := newTestActionUser(userId)
+			user.IsActive = &userRequest.IsActive
+			return user, nil, nil
+		}
+
+		_, _, response, _, err := manager.InvokeAction(ctx, disableUserAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument: "user-1",
+		}))
+		require.NoError(t, err)
+		require.False(t, response.GetFields()["is_active"].GetBoolValue())
+	})
+
+	t.Run("should not update a user already enabled", func(t *testing.T) {
+		manager, mockClientService := newTestActionManager()
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return newTestActionUser(userId), nil, nil
+		}
+
+		_, _, response, _, err := manager.InvokeAction(ctx, enableUserAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument: "user-1",
+		}))
+		require.NoError(t, err)
+		require.True(t, response.GetFields()["is_active"].GetBoolValue())
+	})
+
+	t.Run("should refuse to disable the owner of the connector access key", func(t *testing.T) {
+		manager, mockClientService := newTestActionManager()
+		mockClientService.GetPersonalAccessKeysFunc = connectorKeys
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return newTestActionUser(userId), nil, nil
+		}
+
+		_, _, _, _, err := manager.InvokeAction(ctx, disableUserAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument: "admin-1",
+		}))
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("should disable instead of delete when deprovisioning disables users", func(t *testing.T) {
+		userBuilder, mockClientService := newTestUserBuilder()
+		userBuilder.disableOnDeprovision = true
+		mockClientService.GetPersonalAccessKeysFunc = connectorKeys
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			return newTestActionUser(userId), nil, nil
+		}
+		disabled := false
+		mockClientService.UpdateUserFunc = func(
+			ctx context.Context,
+			userId string,
+			userRequest client.UserUpdateRequest,
+		) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			disabled = !userRequest.IsActive
+			return newTestActionUser(userId), nil, nil
+		}
+
+		_, err := userBuilder.Delete(ctx, &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "user-1"})
+		require.NoError(t, err)
+		require.True(t, disabled)
+	})
+}
+
+func TestUserHelpdeskActions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should unlock the user", func(t *testing.T) {
+		manager, mockClientService := newTestActionManager()
+		unlocked := ""
+		mockClientService.UnlockUserFunc = func(ctx context.Context, userId string) (*v2.RateLimitDescription, error) {
+			unlocked = userId
+			return nil, nil
+		}
+
+		_, actionStatus, response, _, err := manager.InvokeAction(ctx, unlockUserAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument: "user-1",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, v2.BatonActionStatus_BATON_ACTION_STATUS_COMPLETE, actionStatus)
+		require.Equal(t, "user-1", unlocked)
+		require.False(t, response.GetFields()["is_locked"].GetBoolValue())
+	})
+
+	t.Run("should reset the user password", func(t *testing.T) {
+		manager, mockClientService := newTestActionManager()
+		reset := ""
+		mockClientService.ResetPasswordFunc = func(ctx context.Context, userId string) (*v2.RateLimitDescription, error) {
+			reset = userId
+			return nil, nil
+		}
+
+		_, _, response, _, err := manager.InvokeAction(ctx, resetPasswordAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument: "user-1",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, "user-1", reset)
+		require.True(t, response.GetFields()["success"].GetBoolValue())
+	})
+
+	t.Run("should report a failed unlock", func(t *testing.T) {
+		manager, mockClientService := newTestActionManager()
+		mockClientService.UnlockUserFunc = func(ctx context.Context, userId string) (*v2.RateLimitDescription, error) {
+			return nil, status.Error(codes.NotFound, "user:not_found")
+		}
+
+		_, actionStatus, _, _, err := manager.InvokeAction(ctx, unlockUserAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument: "user-1",
+		}))
+		require.Equal(t, codes.NotFound, status.Code(err))
+		require.Equal(t, v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED, actionStatus)
+	})
+}
+
+func TestUserResetMfaAction(t *testing.T) {
+	ctx := context.Background()
+
+	newResetMfaManager := func(mfaEnabled *bool) (*actionManager, *client.MockClientService) {
+		manager, mockClientService := newTestActionManager()
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			user := newTestActionUser(userId)
+			user.IsMfaEnabled = mfaEnabled
+			return user, nil, nil
+		}
+		return manager, mockClientService
+	}
+
+	t.Run("should disable MFA with the user confirmation", func(t *testing.T) {
+		mfaEnabled := true
+		manager, mockClientService := newResetMfaManager(&mfaEnabled)
+		mockClientService.DisableMfaFunc = func(ctx context.Context, userId string, email string, password string) (*v2.RateLimitDescription, error) {
+			require.Equal(t, "user-1", userId)
+			require.Equal(t, "jane.doe@example.com", email)
+			require.Equal(t, " secret ", password)
+			mfaEnabled = false
+			return nil, nil
+		}
+
+		_, _, response, _, err := manager.InvokeAction(ctx, resetMfaAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument:   "user-1",
+			passwordArgument: " secret ",
+		}))
+		require.NoError(t, err)
+		require.False(t, response.GetFields()["mfa_enabled"].GetBoolValue())
+	})
+
+	t.Run("should require the user password", func(t *testing.T) {
+		manager, _ := newResetMfaManager(nil)
+
+		_, _, _, _, err := manager.InvokeAction(ctx, resetMfaAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument: "user-1",
+		}))
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.ErrorContains(t, err, "requires the password of the user")
+	})
+
+	t.Run("should explain a rejected confirmation", func(t *testing.T) {
+		manager, mockClientService := newResetMfaManager(nil)
+		mockClientService.DisableMfaFunc = func(ctx context.Context, userId string, email string, password string) (*v2.RateLimitDescription, error) {
+			return nil, status.Error(codes.Unauthenticated, "request failed with status 401")
+		}
+
+		_, _, _, _, err := manager.InvokeAction(ctx, resetMfaAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument:   "user-1",
+			emailArgument:    "jane@example.com",
+			passwordArgument: "wrong",
+		}))
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.ErrorContains(t, err, "rejected the email and password")
+	})
+
+	t.Run("should not blame the confirmation when the connector lacks permission", func(t *testing.T) {
+		manager, mockClientService := newResetMfaManager(nil)
+		mockClientService.DisableMfaFunc = func(ctx context.Context, userId string, email string, password string) (*v2.RateLimitDescription, error) {
+			return nil, status.Error(codes.PermissionDenied, "request failed with status 403")
+		}
+
+		_, _, _, _, err := manager.InvokeAction(ctx, resetMfaAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument:   "user-1",
+			emailArgument:    "jane@example.com",
+			passwordArgument: "secret",
+		}))
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+		require.ErrorContains(t, err, capabilityManageUsersAndRoles)
+	})
+}
+
+func TestUserDeleteWithContentTransfer(t *testing.T) {
+	ctx := context.Background()
+	resourceID := &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "user-1"}
+
+	// newTransferMocks registers a user-1 to delete, an active successor-1 and an inactive former-1.
+	newTransferMocks := func(mockClientService *client.MockClientService) *string {
+		deleted := false
+		transferredTo := new(string)
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			if userId == "user-1" && deleted {
+				return nil, nil, status.Error(codes.NotFound, "user:not_found")
 			}
-			return users, nil, nil, nil
+			user := newTestActionUser(userId)
+			if userId == "former-1" {
+				isActive := false
+				user.IsActive = &isActive
+			}
+			return user, nil, nil
+		}
+		mockClientService.GetUsersFunc = func(ctx context.Context, pageToken *string) ([]*client.UserResponse, *string, *v2.RateLimitDescription, error) {
+			successor := newTestActionUser("successor-1")
+			successor.Email = "successor@example.com"
+			return []*client.UserResponse{newTestActionUser("user-1"), successor}, nil, nil, nil
 		}
+		mockClientService.DeleteUserFunc = func(ctx context.Context, userId string, transferTo string) (*v2.RateLimitDescription, error) {
+			deleted = true
+			*transferredTo = transferTo
+			return nil, nil
+		}
+		return transferredTo
+	}
 
-		resources, token, annotations, err := userBuilder.List(ctx, nil, &pagination.Token{})
+	t.Run("should transfer the content to the configured successor", func(t *testing.T) {
+		userBuilder, mockClientService := newTestUserBuilder()
+		userBuilder.contentSuccessor = "successor-1"
+		transferredTo := newTransferMocks(mockClientService)
 
-		// Assert the returned user has an ID.
-		require.NotNil(t, resources)
-		require.Len(t, resources, 2)
-		require.NotEmpty(t, resources[0].Id)
-		require.NotEmpty(t, resources[1].Id)
+		_, err := userBuilder.Delete(ctx, resourceID)
+		require.NoError(t, err)
+		require.Equal(t, "successor-1", *transferredTo)
+	})
 
-		require.NotNil(t, token)
-		test.AssertNoRatelimitAnnotations(t, annotations)
-		require.Nil(t, err)
+	t.Run("should refuse an inactive successor", func(t *testing.T) {
+		userBuilder, mockClientService := newTestUserBuilder()
+		userBuilder.contentSuccessor = "former-1"
+		transferredTo := newTransferMocks(mockClientService)
+
+		_, err := userBuilder.Delete(ctx, resourceID)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Empty(t, *transferredTo)
+	})
+
+	t.Run("should refuse to transfer the content to the deleted user", func(t *testing.T) {
+		userBuilder, mockClientService := newTestUserBuilder()
+		userBuilder.contentSuccessor = "user-1"
+		newTransferMocks(mockClientService)
+
+		_, err := userBuilder.Delete(ctx, resourceID)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("should resolve a successor email given to the delete action", func(t *testing.T) {
+		manager, mockClientService := newTestActionManager()
+		manager.userContentSuccessor = "former-1"
+		transferredTo := newTransferMocks(mockClientService)
+
+		_, _, _, _, err := manager.InvokeAction(ctx, deleteUserAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument:     "user-1",
+			transferToArgument: "Successor@example.com",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, "successor-1", *transferredTo)
+	})
+}
+
+func TestUserChangeEmailAction(t *testing.T) {
+	ctx := context.Background()
+
+	newChangeEmailManager := func(confirmed bool) (*actionManager, *client.MockClientService, *string) {
+		manager, mockClientService := newTestActionManager()
+		requested := new(string)
+		mockClientService.GetUserByIDFunc = func(ctx context.Context, userId string) (*client.UserResponse, *v2.RateLimitDescription, error) {
+			user := newTestActionUser(userId)
+			if confirmed && *requested != "" {
+				user.Email = *requested
+			}
+			return user, nil, nil
+		}
+		mockClientService.RequestChangeEmailFunc = func(ctx context.Context, userId string, email string) (*v2.RateLimitDescription, error) {
+			*requested = email
+			return nil, nil
+		}
+		return manager, mockClientService, requested
+	}
+
+	t.Run("should report the change as pending until it is confirmed", func(t *testing.T) {
+		manager, _, requested := newChangeEmailManager(false)
+
+		_, _, response, _, err := manager.InvokeAction(ctx, changeEmailAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument:   "user-1",
+			newEmailArgument: "jane.smith@example.com",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, "jane.smith@example.com", *requested)
+		require.Equal(t, emailChangePending, response.GetFields()["status"].GetStringValue())
+		require.Equal(t, "jane.doe@example.com", response.GetFields()[emailArgument].GetStringValue())
+	})
+
+	t.Run("should report a confirmed change", func(t *testing.T) {
+		manager, _, _ := newChangeEmailManager(true)
+
+		_, _, response, _, err := manager.InvokeAction(ctx, changeEmailAction, newActionArgs(t, map[string]interface{}{
+			userIDArgument:   "user-1",
+			newEmailArgument: "jane.smith@example.com",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, emailChangeConfirmed, response.GetFields()["status"].GetStringValue())
+	})
+
+	t.Run("should validate the new email", func(t *testing.T) {
+		manager, _, requested := newChangeEmailManager(false)
+
+		for _, email := range []string{"jane", "Jane <jane@example.com>", "JANE.DOE@example.com"} {
+			_, _, _, _, err := manager.InvokeAction(ctx, changeEmailAction, newActionArgs(t, map[string]interface{}{
+				userIDArgument:   "user-1",
+				newEmailArgument: email,
+			}))
+			require.Equal(t, codes.InvalidArgument, status.Code(err), email)
+		}
+		require.Empty(t, *requested)
 	})
 }
@@ -0,0 +1,130 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/conductorone/baton-sumo-logic/pkg/client"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	capabilityViewUsersAndRoles   = "viewUsersAndRoles"
+	capabilityManageUsersAndRoles = "manageUsersAndRoles"
+	capabilityManageAccessKeys    = "manageAccessKeys"
+)
+
+// validationError turns an API error raised while validating into an actionable message.
+func validationError(operation string, err error) error {
+	var redirectErr *client.DeploymentRedirectError
+	if errors.As(err, &redirectErr) {
+		return fmt.Errorf(
+			"baton-sumo-logic: failed to %s: the api-base-url does not match the deployment of the account, "+
+				"Sumo Logic redirected the request to %s: %w",
+			operation,
+			redirectErr.Location,
+			err,
+		)
+	}
+
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		return fmt.Errorf(
+			"baton-sumo-logic: failed to %s: the api-access-id or api-access-key is invalid, "+
+				"or the access key belongs to another deployment than the api-base-url: %w",
+			operation,
+			err,
+		)
+	case codes.PermissionDenied:
+		return fmt.Errorf(
+			"baton-sumo-logic: failed to %s: the access key is not allowed to %s, "+
+				"make sure its owner holds the %s or %s capability: %w",
+			operation,
+			operation,
+			capabilityViewUsersAndRoles,
+			capabilityManageUsersAndRoles,
+			err,
+		)
+	default:
+		return fmt.Errorf("baton-sumo-logic: failed to %s: %w", operation, err)
+	}
+}
+
+// validateCapabilities checks that the owner of the access key holds the capabilities needed by the enabled features.
+// The check is skipped when the owner or their capabilities cannot be resolved, since the API calls made so far succeeded.
+func (d *Connector) validateCapabilities(ctx context.Context) error {
+	l := ctxzap.Extract(ctx)
+
+	capabilities, err := d.accessKeyCapabilities(ctx)
+	if err != nil {
+		l.Warn("baton-sumo-logic: unable to resolve the capabilities of the access key, skipping the capability check", zap.Error(err))
+		return nil
+	}
+
+	var missing []string
+	// Managing users and roles also allows viewing them.
+	if !slices.Contains(capabilities, capabilityViewUsersAndRoles) && !slices.Contains(capabilities, capabilityManageUsersAndRoles) {
+		missing = append(missing, capabilityViewUsersAndRoles)
+	}
+	if d.provisioningEnabled && !slices.Contains(capabilities, capabilityManageUsersAndRoles) {
+		missing = append(missing, capabilityManageUsersAndRoles)
+	}
+	// Listing the access keys of every account requires managing them.
+	if d.includeAccessKeys && !slices.Contains(capabilities, capabilityManageAccessKeys) {
+		missing = append(missing, capabilityManageAccessKeys)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("baton-sumo-logic: the access key is missing the required capabilities: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// accessKeyCapabilities returns the capabilities held through the roles of the user who owns the configured access key.
+func (d *Connector) accessKeyCapabilities(ctx context.Context) ([]string, error) {
+	owner, err := accessKeyOwner(ctx, d.service, d.apiAccessID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, _, err := d.service.GetUserByID(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access key owner %s: %w", owner, err)
+	}
+
+	var capabilities []string
+	for _, roleID := range user.RoleIDs {
+		role, _, err := d.service.GetRole(ctx, roleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get role %s: %w", roleID, err)
+		}
+		if role.Capabilities != nil {
+			capabilities = append(capabilities, *role.Capabilities...)
+		}
+	}
+
+	return capabilities, nil
+}
+
+// accessKeyOwner returns the ID of the user who owns the access key the connector is authenticated with.
+func accessKeyOwner(ctx context.Context, service client.ClientService, apiAccessID string) (string, error) {
+	accessKeys, _, err := service.GetPersonalAccessKeys(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list personal access keys: %w", err)
+	}
+
+	for _, accessKey := range accessKeys {
+		if isConnectorAccessKey(apiAccessID, accessKey.ID) && accessKey.CreatedBy != "" {
+			return accessKey.CreatedBy, nil
+		}
+	}
+
+	return "", fmt.Errorf("access key %s not found in the personal access keys", apiAccessID)
+}